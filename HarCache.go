@@ -0,0 +1,153 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// 缓存格式版本（分析逻辑或结果结构变化时递增，使旧缓存失效）
+const analysisCacheVersion = 15
+
+// 默认缓存目录（位于输出目录下）
+const defaultCacheDirName = ".cache"
+
+// 缓存条目
+type analysisCacheEntry struct {
+	Version     int                      `json:"version"`
+	ContentHash string                   `json:"contentHash"`
+	ConfigHash  string                   `json:"configHash"`
+	Result      *UniversalAnalysisResult `json:"result"`
+}
+
+// 注册 -cache-dir 和 -no-cache 参数
+func addCacheFlags(flags *flag.FlagSet, analyzer *UniversalHARAnalyzer) {
+	flags.StringVar(&analyzer.cacheDir, "cache-dir", "", T("flag.cache_dir", defaultCacheDirName))
+	flags.BoolVar(&analyzer.noCache, "no-cache", false, T("flag.no_cache"))
+}
+
+// 缓存目录
+func (ua *UniversalHARAnalyzer) analysisCacheDir() string {
+	if ua.cacheDir != "" {
+		return ua.cacheDir
+	}
+	return filepath.Join(ua.outputDir, defaultCacheDirName)
+}
+
+// 影响分析结果的配置指纹（包含 AnalyzeHAR 读取的所有配置；章节、输出目录等不参与计算）
+func (ua *UniversalHARAnalyzer) analysisConfigHash() string {
+	config := ua.settings()
+	data, _ := json.Marshal(struct {
+		Version          int
+		Language         string
+		Filter           string
+		ImportantHeaders []string
+		Thresholds       ReportThresholds
+		ContentTypes     []ContentTypeRule
+		Redact           []RedactionRule
+		ProtoDescriptors string
+	}{
+		Version:          analysisCacheVersion,
+		Language:         currentLanguage,
+		Filter:           ua.filter.String(),
+		ImportantHeaders: config.ImportantHeaders,
+		Thresholds:       config.Thresholds,
+		ContentTypes:     config.ContentTypes,
+		Redact:           config.Redact,
+		ProtoDescriptors: ua.protoDescriptors.fingerprintOrEmpty(),
+	})
+	hash := sha256.Sum256(data)
+	return hex.EncodeToString(hash[:])
+}
+
+// 缓存文件路径（按内容哈希和配置指纹命名）
+func (ua *UniversalHARAnalyzer) analysisCachePath(contentHash string) string {
+	return filepath.Join(ua.analysisCacheDir(), contentHash+"_"+ua.analysisConfigHash()[:12]+".json")
+}
+
+// 读取缓存的分析结果（缓存不存在、损坏或版本不匹配时返回nil）
+func (ua *UniversalHARAnalyzer) loadCachedAnalysis(filePath, contentHash string) *UniversalAnalysisResult {
+	if ua.noCache {
+		return nil
+	}
+	data, err := os.ReadFile(ua.analysisCachePath(contentHash))
+	if err != nil {
+		return nil
+	}
+
+	var entry analysisCacheEntry
+	if err := json.Unmarshal(data, &entry); err != nil || entry.Result == nil {
+		return nil
+	}
+	if entry.Version != analysisCacheVersion || entry.ContentHash != contentHash || entry.ConfigHash != ua.analysisConfigHash() {
+		return nil
+	}
+
+	// 相同内容的文件可能位于不同路径
+	result := entry.Result
+	result.Metadata.FileName = filepath.Base(filePath)
+	result.Metadata.FilePath = filePath
+	return result
+}
+
+// 保存分析结果到缓存（先写临时文件再重命名，避免中断时留下不完整的缓存）
+func (ua *UniversalHARAnalyzer) storeCachedAnalysis(contentHash string, result *UniversalAnalysisResult) error {
+	if err := os.MkdirAll(ua.analysisCacheDir(), 0755); err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(analysisCacheEntry{
+		Version:     analysisCacheVersion,
+		ContentHash: contentHash,
+		ConfigHash:  ua.analysisConfigHash(),
+		Result:      result,
+	})
+	if err != nil {
+		return err
+	}
+
+	path := ua.analysisCachePath(contentHash)
+	temp, err := os.CreateTemp(filepath.Dir(path), ".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(temp.Name())
+	if _, err := temp.Write(data); err != nil {
+		temp.Close()
+		return err
+	}
+	if err := temp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(temp.Name(), path)
+}
+
+// 分析单个HAR文件，内容和配置未变化时直接使用缓存结果
+// （启用明细导出时仍需解析HAR文件，返回的harFile为nil表示未解析）
+func (ua *UniversalHARAnalyzer) analyzeHARFileCached(filePath string) (*UniversalAnalysisResult, *UniversalHARFile, bool, error) {
+	contentHash, err := fileSHA256(filePath)
+	if err != nil {
+		return nil, nil, false, fmt.Errorf("%s: %w", T("err.read_har"), err)
+	}
+
+	result := ua.loadCachedAnalysis(filePath, contentHash)
+	var harFile *UniversalHARFile
+	if result == nil || len(ua.exportFormats) > 0 {
+		if harFile, err = ua.LoadHARFile(filePath); err != nil {
+			return nil, nil, false, err
+		}
+	}
+	if result != nil {
+		return result, harFile, true, nil
+	}
+
+	result = ua.AnalyzeHAR(harFile, filePath)
+	if err := ua.storeCachedAnalysis(contentHash, result); err != nil {
+		fmt.Println("⚠️ " + T("cache.store_failed", err))
+	}
+	return result, harFile, false, nil
+}
@@ -0,0 +1,302 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// 响应的可缓存性
+const (
+	cacheFresh        = "fresh"         // 有明确的新鲜期（max-age、Expires）
+	cacheHeuristic    = "heuristic"     // 没有新鲜期，浏览器按 Last-Modified 启发式缓存
+	cacheRevalidate   = "revalidate"    // 每次使用前都要再验证（no-cache、max-age=0、已过期）
+	cacheNoStore      = "no_store"      // 禁止缓存
+	cacheNotCacheable = "not_cacheable" // 方法或状态码不可缓存
+	cacheUnspecified  = "unspecified"   // 没有任何缓存相关的响应头
+)
+
+// 默认可缓存的状态码（RFC 9111 第4.2.2节）
+var heuristicallyCacheableStatus = map[int]bool{
+	200: true, 203: true, 204: true, 206: true, 300: true, 301: true, 308: true,
+	404: true, 405: true, 410: true, 414: true, 501: true,
+}
+
+// 缓存审计结果
+type CacheAnalysis struct {
+	Responses         int                `json:"responses"`
+	FromCache         int                `json:"fromCache"`         // 直接从浏览器缓存读取的响应
+	Cacheability      map[string]int     `json:"cacheability"`      // 按可缓存性分类的响应数
+	Conditional       int                `json:"conditional"`       // 条件请求数（If-None-Match、If-Modified-Since）
+	NotModified       int                `json:"notModified"`       // 304响应数
+	Repeated          []RepeatedDownload `json:"repeated"`          // 同一地址重复下载的相同内容（计入节省估算）
+	Duplicates        []RepeatedDownload `json:"duplicates"`        // 不同地址下载的相同内容（HTTP缓存无法合并，仅供参考）
+	MissingValidators []CacheAsset       `json:"missingValidators"` // 没有 ETag 和 Last-Modified 的静态资源
+	Hosts             []CacheHost        `json:"hosts"`
+	SavedBytes        int64              `json:"savedBytes"` // 合理缓存后可节省的传输字节数
+	SavedTime         float64            `json:"savedTime"`  // 合理缓存后可节省的请求耗时（毫秒）
+}
+
+// 重复下载的相同内容
+type RepeatedDownload struct {
+	URL          string  `json:"url"`          // 第一次下载的地址
+	URLs         int     `json:"urls"`         // 内容相同的不同地址数（同一地址的重复下载为1）
+	Downloads    int     `json:"downloads"`    // 下载次数
+	Cacheability string  `json:"cacheability"` // 第一次下载时的可缓存性
+	WastedBytes  int64   `json:"wastedBytes"`  // 第一次之后的传输字节数
+	WastedTime   float64 `json:"wastedTime"`   // 第一次之后的请求耗时（毫秒）
+}
+
+// 缺少验证器的静态资源
+type CacheAsset struct {
+	URL          string `json:"url"`
+	Kind         string `json:"kind"`
+	CacheControl string `json:"cacheControl,omitempty"`
+	Bytes        int64  `json:"bytes"`
+}
+
+// 按主机统计的缓存情况
+type CacheHost struct {
+	Host        string `json:"host"`
+	Responses   int    `json:"responses"`
+	Cacheable   int    `json:"cacheable"` // 有新鲜期或可启发式缓存的响应
+	FromCache   int    `json:"fromCache"`
+	Conditional int    `json:"conditional"`
+	NotModified int    `json:"notModified"`
+	WastedBytes int64  `json:"wastedBytes"` // 重复下载的字节数
+}
+
+// 解析 Cache-Control 指令（名称小写，值去掉引号）
+func parseCacheControl(value string) map[string]string {
+	directives := make(map[string]string)
+	for _, part := range strings.Split(value, ",") {
+		name, argument, _ := strings.Cut(strings.TrimSpace(part), "=")
+		if name = strings.ToLower(strings.TrimSpace(name)); name != "" {
+			directives[name] = strings.Trim(strings.TrimSpace(argument), `"`)
+		}
+	}
+	return directives
+}
+
+// 判断响应的可缓存性（浏览器私有缓存的视角，忽略 s-maxage）
+func cacheability(entry *HAREntry) string {
+	method := strings.ToUpper(entry.Request.Method)
+	if method != "GET" && method != "HEAD" {
+		return cacheNotCacheable
+	}
+	headers := entry.Response.Headers
+	cacheControl := headerValue(headers, "Cache-Control")
+	directives := parseCacheControl(cacheControl)
+	if _, noStore := directives["no-store"]; noStore {
+		return cacheNoStore
+	}
+
+	// 新鲜期：max-age 优先于 Expires，减去响应在缓存中已经停留的 Age
+	lifetime, explicit := time.Duration(0), false
+	if maxAge, ok := directives["max-age"]; ok {
+		seconds, _ := strconv.Atoi(maxAge)
+		lifetime, explicit = time.Duration(seconds)*time.Second, true
+	} else if expires := headerValue(headers, "Expires"); expires != "" {
+		explicit = true
+		expiresAt, err := http.ParseTime(expires)
+		date, dateErr := http.ParseTime(headerValue(headers, "Date"))
+		if dateErr != nil {
+			date, dateErr = time.Parse(time.RFC3339, entry.StartedDateTime)
+		}
+		if err == nil && dateErr == nil {
+			lifetime = expiresAt.Sub(date)
+		}
+	}
+	if age, err := strconv.Atoi(strings.TrimSpace(headerValue(headers, "Age"))); err == nil {
+		lifetime -= time.Duration(age) * time.Second
+	}
+
+	_, noCache := directives["no-cache"]
+	if cacheControl == "" && strings.Contains(strings.ToLower(headerValue(headers, "Pragma")), "no-cache") {
+		noCache = true
+	}
+	status := entry.Response.Status
+	if status == 304 {
+		// 304 更新已缓存响应的元数据，按原响应的状态码判断
+		status = 200
+	}
+	switch {
+	case noCache || explicit && lifetime <= 0:
+		return cacheRevalidate
+	case explicit:
+		return cacheFresh
+	case !heuristicallyCacheableStatus[status]:
+		return cacheNotCacheable
+	case headerValue(headers, "Last-Modified") != "":
+		return cacheHeuristic
+	case headerValue(headers, "ETag") != "":
+		return cacheRevalidate
+	}
+	return cacheUnspecified
+}
+
+// 响应是否直接从浏览器缓存读取（Chrome 的 _fromCache，或缓存中已有条目且没有传输响应体）
+func servedFromCache(entry *HAREntry) bool {
+	if entry.FromCache != "" {
+		return true
+	}
+	return entry.Cache.BeforeRequest != nil && entry.Response.Status == 200 &&
+		entry.Response.BodySize == 0 && entry.Response.Content.Size > 0
+}
+
+// 是否为条件请求
+func isConditionalRequest(entry *HAREntry) bool {
+	return headerValue(entry.Request.Headers, "If-None-Match") != "" || headerValue(entry.Request.Headers, "If-Modified-Since") != ""
+}
+
+// 审计缓存效果（没有响应时返回nil）
+func (ua *UniversalHARAnalyzer) analyzeCaching(entries []HAREntry) *CacheAnalysis {
+	analysis := &CacheAnalysis{Cacheability: make(map[string]int)}
+	hosts := make(map[string]*CacheHost)
+	type download struct {
+		repeated *RepeatedDownload
+		urls     map[string]bool
+		host     *CacheHost
+	}
+	repeats := make(map[string]*download)  // 地址+内容 → 下载记录
+	contents := make(map[string]*download) // 内容 → 下载记录（不区分地址）
+	var repeatOrder, contentOrder []string
+	missingValidators := make(map[string]bool)
+
+	for i := range entries {
+		entry := &entries[i]
+		if entry.Response.Status == 0 {
+			continue
+		}
+		normalized := normalizeURL(entry.Request.URL)
+		host, exists := hosts[normalized.Authority()]
+		if !exists {
+			host = &CacheHost{Host: normalized.Authority()}
+			hosts[host.Host] = host
+		}
+		analysis.Responses++
+		host.Responses++
+
+		class := cacheability(entry)
+		analysis.Cacheability[class]++
+		if class == cacheFresh || class == cacheHeuristic {
+			host.Cacheable++
+		}
+		if servedFromCache(entry) {
+			analysis.FromCache++
+			host.FromCache++
+			continue
+		}
+		if isConditionalRequest(entry) {
+			analysis.Conditional++
+			host.Conditional++
+		}
+		if entry.Response.Status == 304 {
+			analysis.NotModified++
+			host.NotModified++
+			// 响应仍在新鲜期内（明确的或启发式的）却被再验证时，这次往返可以省去
+			if class == cacheFresh || class == cacheHeuristic {
+				analysis.SavedTime += entry.Time
+			}
+			continue
+		}
+		if entry.Response.Status != 200 || strings.ToUpper(entry.Request.Method) != "GET" {
+			continue
+		}
+
+		size := transferBytes(entry)
+		urlKey := redirectURLKey(entry.Request.URL)
+		if isStaticResource(entry) && !missingValidators[urlKey] &&
+			headerValue(entry.Response.Headers, "ETag") == "" && headerValue(entry.Response.Headers, "Last-Modified") == "" {
+			missingValidators[urlKey] = true
+			analysis.MissingValidators = append(analysis.MissingValidators, CacheAsset{
+				URL:          ua.redactURL(entry.Request.URL),
+				Kind:         resourceKind(entry),
+				CacheControl: headerValue(entry.Response.Headers, "Cache-Control"),
+				Bytes:        size,
+			})
+		}
+
+		// 按内容哈希识别重复下载（没有响应体时按地址和大小）
+		var key string
+		if body := responseBodyText(entry); body != "" {
+			sum := sha256.Sum256([]byte(body))
+			key = hex.EncodeToString(sum[:])
+		} else if entry.Response.Content.Size > 0 {
+			key = redirectURLKey(entry.Request.URL) + "\x00" + strconv.FormatFloat(entry.Response.Content.Size, 'f', -1, 64)
+		} else {
+			continue
+		}
+		// HTTP缓存按地址存储，只有同一地址的重复下载可以通过缓存避免
+		add := func(downloads map[string]*download, order *[]string, key string) *download {
+			current, exists := downloads[key]
+			if !exists {
+				current = &download{
+					repeated: &RepeatedDownload{URL: ua.redactURL(entry.Request.URL), Cacheability: class},
+					urls:     make(map[string]bool),
+					host:     host,
+				}
+				downloads[key] = current
+				*order = append(*order, key)
+			} else {
+				current.repeated.WastedBytes += size
+				current.repeated.WastedTime += entry.Time
+			}
+			current.repeated.Downloads++
+			current.urls[urlKey] = true
+			return current
+		}
+		if current := add(repeats, &repeatOrder, urlKey+"\x00"+key); current.repeated.Downloads > 1 {
+			current.host.WastedBytes += size
+		}
+		add(contents, &contentOrder, key)
+	}
+	if analysis.Responses == 0 {
+		return nil
+	}
+
+	for _, key := range repeatOrder {
+		current := repeats[key]
+		if current.repeated.Downloads < 2 {
+			continue
+		}
+		current.repeated.URLs = 1
+		current.repeated.WastedTime = roundMillis(current.repeated.WastedTime)
+		analysis.SavedBytes += current.repeated.WastedBytes
+		analysis.SavedTime += current.repeated.WastedTime
+		analysis.Repeated = append(analysis.Repeated, *current.repeated)
+	}
+	for _, key := range contentOrder {
+		current := contents[key]
+		if len(current.urls) < 2 {
+			continue
+		}
+		current.repeated.URLs = len(current.urls)
+		current.repeated.WastedTime = roundMillis(current.repeated.WastedTime)
+		analysis.Duplicates = append(analysis.Duplicates, *current.repeated)
+	}
+	analysis.SavedTime = roundMillis(analysis.SavedTime)
+	for _, list := range [][]RepeatedDownload{analysis.Repeated, analysis.Duplicates} {
+		sort.SliceStable(list, func(i, j int) bool {
+			return list[i].WastedBytes > list[j].WastedBytes
+		})
+	}
+	sort.SliceStable(analysis.MissingValidators, func(i, j int) bool {
+		return analysis.MissingValidators[i].Bytes > analysis.MissingValidators[j].Bytes
+	})
+
+	for _, host := range hosts {
+		analysis.Hosts = append(analysis.Hosts, *host)
+	}
+	sort.Slice(analysis.Hosts, func(i, j int) bool {
+		if analysis.Hosts[i].Responses != analysis.Hosts[j].Responses {
+			return analysis.Hosts[i].Responses > analysis.Hosts[j].Responses
+		}
+		return analysis.Hosts[i].Host < analysis.Hosts[j].Host
+	})
+	return analysis
+}
@@ -0,0 +1,213 @@
+package main
+
+import (
+	"bytes"
+	"compress/gzip"
+	"sort"
+	"strings"
+
+	"github.com/andybalholm/brotli"
+)
+
+// 小于该大小的响应压缩收益很小，不计入未压缩的响应
+const minCompressibleSize = 1024
+
+// 可压缩的内容类型关键字（图片、音视频、woff字体和压缩包本身已经压缩）
+var compressibleContentTypes = []string{
+	"text/", "json", "javascript", "ecmascript", "xml", "svg", "css", "html",
+	"wasm", "font/ttf", "font/otf", "sfnt", "ms-fontobject", "manifest", "graphql", "x-yaml", "csv",
+}
+
+// 压缩审计结果
+type CompressionAnalysis struct {
+	Responses    int                   `json:"responses"`    // 有响应体的响应数
+	Compressed   int                   `json:"compressed"`   // 使用了 Content-Encoding 的响应数
+	ContentBytes int64                 `json:"contentBytes"` // 解压后的响应体总大小
+	BodyBytes    int64                 `json:"bodyBytes"`    // 实际传输的响应体总大小
+	SavableBytes int64                 `json:"savableBytes"` // 压缩未压缩的响应后可节省的字节数
+	Encodings    []CompressionEncoding `json:"encodings"`    // 按 Content-Encoding 统计
+	Uncompressed []UncompressedEntry   `json:"uncompressed"` // 未压缩的可压缩响应
+	Hosts        []CompressionGroup    `json:"hosts"`        // 按主机统计可节省的字节
+	ContentTypes []CompressionGroup    `json:"contentTypes"` // 按内容类型统计可节省的字节
+}
+
+// 一种 Content-Encoding 的统计（identity 表示未压缩）
+type CompressionEncoding struct {
+	Name         string `json:"name"`
+	Responses    int    `json:"responses"`
+	ContentBytes int64  `json:"contentBytes"`
+	BodyBytes    int64  `json:"bodyBytes"`
+}
+
+// 传输大小占原始大小的百分比
+func (e CompressionEncoding) Ratio() float64 {
+	if e.ContentBytes == 0 {
+		return 0
+	}
+	return float64(e.BodyBytes) * 100 / float64(e.ContentBytes)
+}
+
+// 未压缩的可压缩响应
+type UncompressedEntry struct {
+	URL          string `json:"url"`
+	MimeType     string `json:"mimeType"`
+	Size         int64  `json:"size"`                   // 传输的响应体大小
+	GzipSize     int64  `json:"gzipSize,omitempty"`     // 本地gzip压缩后的大小（没有响应体时为0）
+	BrotliSize   int64  `json:"brotliSize,omitempty"`   // 本地brotli压缩后的大小
+	SavableBytes int64  `json:"savableBytes,omitempty"` // 使用较小的压缩结果可节省的字节数
+}
+
+// 按主机或内容类型汇总的压缩情况
+type CompressionGroup struct {
+	Name         string `json:"name"`
+	Responses    int    `json:"responses"`
+	Uncompressed int    `json:"uncompressed"` // 未压缩的可压缩响应数
+	BodyBytes    int64  `json:"bodyBytes"`
+	SavableBytes int64  `json:"savableBytes"`
+}
+
+// 内容类型是否值得压缩
+func isCompressibleType(mimeType string) bool {
+	mimeType = strings.ToLower(mimeType)
+	for _, keyword := range compressibleContentTypes {
+		if strings.Contains(mimeType, keyword) {
+			return true
+		}
+	}
+	return false
+}
+
+// 本地压缩后的大小（gzip和brotli均使用默认压缩级别）
+func compressedSizes(data []byte) (gzipSize, brotliSize int64) {
+	var buffer bytes.Buffer
+	gzipWriter := gzip.NewWriter(&buffer)
+	gzipWriter.Write(data)
+	gzipWriter.Close()
+	gzipSize = int64(buffer.Len())
+
+	buffer.Reset()
+	brotliWriter := brotli.NewWriterLevel(&buffer, brotli.DefaultCompression)
+	brotliWriter.Write(data)
+	brotliWriter.Close()
+	return gzipSize, int64(buffer.Len())
+}
+
+// 审计响应压缩（没有响应体时返回nil）
+func (ua *UniversalHARAnalyzer) analyzeCompression(entries []HAREntry) *CompressionAnalysis {
+	analysis := &CompressionAnalysis{}
+	encodings := make(map[string]*CompressionEncoding)
+	hosts := make(map[string]*CompressionGroup)
+	contentTypes := make(map[string]*CompressionGroup)
+	group := func(groups map[string]*CompressionGroup, name string) *CompressionGroup {
+		if _, exists := groups[name]; !exists {
+			groups[name] = &CompressionGroup{Name: name}
+		}
+		return groups[name]
+	}
+
+	for i := range entries {
+		entry := &entries[i]
+		if entry.Response.Status == 0 || entry.Response.Status == 304 || servedFromCache(entry) {
+			continue
+		}
+		contentSize := int64(entry.Response.Content.Size)
+		bodySize := int64(entry.Response.BodySize)
+		if bodySize <= 0 {
+			// 没有记录传输大小时按未压缩处理
+			bodySize = contentSize
+		}
+		if contentSize <= 0 && bodySize <= 0 {
+			continue
+		}
+
+		encoding := strings.ToLower(strings.TrimSpace(headerValue(entry.Response.Headers, "Content-Encoding")))
+		if encoding == "" {
+			encoding = "identity"
+		}
+		mimeType := entry.Response.Content.MimeType
+		if index := strings.Index(mimeType, ";"); index >= 0 {
+			mimeType = mimeType[:index]
+		}
+		mimeType = strings.ToLower(strings.TrimSpace(mimeType))
+
+		analysis.Responses++
+		analysis.ContentBytes += contentSize
+		analysis.BodyBytes += bodySize
+		if encoding != "identity" {
+			analysis.Compressed++
+		}
+		if _, exists := encodings[encoding]; !exists {
+			encodings[encoding] = &CompressionEncoding{Name: encoding}
+		}
+		encodings[encoding].Responses++
+		encodings[encoding].ContentBytes += contentSize
+		encodings[encoding].BodyBytes += bodySize
+
+		host := group(hosts, normalizeURL(entry.Request.URL).Authority())
+		contentType := group(contentTypes, mimeType)
+		for _, g := range []*CompressionGroup{host, contentType} {
+			g.Responses++
+			g.BodyBytes += bodySize
+		}
+
+		if encoding != "identity" || !isCompressibleType(mimeType) || bodySize < minCompressibleSize {
+			continue
+		}
+		uncompressed := UncompressedEntry{URL: ua.redactURL(entry.Request.URL), MimeType: mimeType, Size: bodySize}
+		if body := responseBodyText(entry); body != "" {
+			uncompressed.GzipSize, uncompressed.BrotliSize = compressedSizes([]byte(body))
+			if best := min(uncompressed.GzipSize, uncompressed.BrotliSize); best < bodySize {
+				uncompressed.SavableBytes = bodySize - best
+			}
+		}
+		analysis.SavableBytes += uncompressed.SavableBytes
+		analysis.Uncompressed = append(analysis.Uncompressed, uncompressed)
+		for _, g := range []*CompressionGroup{host, contentType} {
+			g.Uncompressed++
+			g.SavableBytes += uncompressed.SavableBytes
+		}
+	}
+	if analysis.Responses == 0 {
+		return nil
+	}
+
+	for _, encoding := range encodings {
+		analysis.Encodings = append(analysis.Encodings, *encoding)
+	}
+	sort.Slice(analysis.Encodings, func(i, j int) bool {
+		if analysis.Encodings[i].Responses != analysis.Encodings[j].Responses {
+			return analysis.Encodings[i].Responses > analysis.Encodings[j].Responses
+		}
+		return analysis.Encodings[i].Name < analysis.Encodings[j].Name
+	})
+	sort.SliceStable(analysis.Uncompressed, func(i, j int) bool {
+		a, b := analysis.Uncompressed[i], analysis.Uncompressed[j]
+		if a.SavableBytes != b.SavableBytes {
+			return a.SavableBytes > b.SavableBytes
+		}
+		return a.Size > b.Size
+	})
+	analysis.Hosts = sortCompressionGroups(hosts)
+	analysis.ContentTypes = sortCompressionGroups(contentTypes)
+	return analysis
+}
+
+// 按可节省字节数（相同时按传输字节数）降序排列，只保留有未压缩响应的分组
+func sortCompressionGroups(groups map[string]*CompressionGroup) []CompressionGroup {
+	var sorted []CompressionGroup
+	for _, g := range groups {
+		if g.Uncompressed > 0 {
+			sorted = append(sorted, *g)
+		}
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].SavableBytes != sorted[j].SavableBytes {
+			return sorted[i].SavableBytes > sorted[j].SavableBytes
+		}
+		if sorted[i].BodyBytes != sorted[j].BodyBytes {
+			return sorted[i].BodyBytes > sorted[j].BodyBytes
+		}
+		return sorted[i].Name < sorted[j].Name
+	})
+	return sorted
+}
@@ -0,0 +1,374 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+)
+
+// 项目级配置文件名（按顺序在当前目录查找）
+var projectConfigFileNames = []string{"har-analyzer.yaml", "har-analyzer.yml", "har-analyzer.toml"}
+
+// 可在配置中启用的报告章节
+var reportSections = []string{
+	"overview", "hosts", "apis", "parameters", "requestBodies", "headers",
+	"methods", "statusCodes", "redirects", "caching", "compression", "pages", "responseTypes", "graphql", "jsonrpc", "grpc", "websocket", "streams", "codeTemplates",
+}
+
+// 默认脱敏替换值
+const defaultRedactionReplacement = "[REDACTED]"
+
+// 分析器配置
+type AnalyzerConfig struct {
+	OutputDir        string            `yaml:"outputDir" toml:"outputDir"`               // 输出目录
+	Language         string            `yaml:"language" toml:"language"`                 // 输出语言
+	Filter           string            `yaml:"filter" toml:"filter"`                     // 请求过滤表达式
+	ExcludeStatic    bool              `yaml:"excludeStatic" toml:"excludeStatic"`       // 排除静态资源
+	ImportantHeaders []string          `yaml:"importantHeaders" toml:"importantHeaders"` // 重要请求头关键字（名称包含任一关键字即视为重要）
+	Thresholds       ReportThresholds  `yaml:"thresholds" toml:"thresholds"`             // 报告阈值
+	MaxItems         int               `yaml:"maxItems" toml:"maxItems"`                 // 统计表最多显示的项数
+	ContentTypes     []ContentTypeRule `yaml:"contentTypes" toml:"contentTypes"`         // 响应类型分类规则（按顺序匹配）
+	Redact           []RedactionRule   `yaml:"redact" toml:"redact"`                     // 脱敏规则
+	Sections         []string          `yaml:"sections" toml:"sections"`                 // 启用的报告章节
+	Naming           string            `yaml:"naming" toml:"naming"`                     // 输出文件命名方式
+	OutputMode       string            `yaml:"outputMode" toml:"outputMode"`             // 输出文件写入模式
+	Watch            WatchConfig       `yaml:"watch" toml:"watch"`                       // watch 子命令设置
+	ProtoDescriptors []string          `yaml:"protoDescriptors" toml:"protoDescriptors"` // 用于解码protobuf的描述符集合文件
+	PageBudgets      []PageBudget      `yaml:"pageBudgets" toml:"pageBudgets"`           // 页面性能预算（按顺序匹配）
+
+	source string // 配置文件路径（使用默认配置时为空）
+}
+
+// 报告阈值（只显示出现次数大于阈值的项）
+type ReportThresholds struct {
+	APICalls        int `yaml:"apiCalls" toml:"apiCalls"`               // 热门API及API端点列表
+	Parameters      int `yaml:"parameters" toml:"parameters"`           // 常用参数
+	Headers         int `yaml:"headers" toml:"headers"`                 // 常用请求头
+	TemplateHeaders int `yaml:"templateHeaders" toml:"templateHeaders"` // 代码模板中的请求头设置
+	Statistics      int `yaml:"statistics" toml:"statistics"`           // HTTP方法、状态码、响应类型统计
+}
+
+// 响应类型分类规则（内容类型包含任一关键字时归为该类型）
+type ContentTypeRule struct {
+	Type  string   `yaml:"type" toml:"type"`
+	Match []string `yaml:"match" toml:"match"`
+}
+
+// watch 子命令设置
+type WatchConfig struct {
+	Interval time.Duration `yaml:"interval" toml:"interval"` // 轮询间隔
+	Settle   time.Duration `yaml:"settle" toml:"settle"`     // 文件修改时间超过该时长且大小不再变化时视为写入完成
+	Debounce time.Duration `yaml:"debounce" toml:"debounce"` // 最后一次变化后等待该时长再更新汇总报告
+	Gate     string        `yaml:"gate" toml:"gate"`         // 汇总报告更新后执行的检查命令
+}
+
+// 页面性能预算（0表示不限制）
+type PageBudget struct {
+	Page             string  `yaml:"page" toml:"page"`                         // 页面标题或地址包含的文本（为空时匹配所有页面）
+	Requests         int     `yaml:"requests" toml:"requests"`                 // 最多请求数
+	TransferBytes    int64   `yaml:"transferBytes" toml:"transferBytes"`       // 最多传输字节数
+	DOMContentLoaded float64 `yaml:"domContentLoaded" toml:"domContentLoaded"` // DOMContentLoaded 时间上限（毫秒）
+	OnLoad           float64 `yaml:"onLoad" toml:"onLoad"`                     // onLoad 时间上限（毫秒）
+	RenderBlocking   int     `yaml:"renderBlocking" toml:"renderBlocking"`     // 最多阻塞渲染的请求数
+}
+
+// 脱敏规则（header、param、cookie、value 四选一）
+type RedactionRule struct {
+	Header      string `yaml:"header" toml:"header"`           // 请求头/响应头名称（不区分大小写）
+	Param       string `yaml:"param" toml:"param"`             // 查询参数名称
+	Cookie      string `yaml:"cookie" toml:"cookie"`           // Cookie名称
+	Value       string `yaml:"value" toml:"value"`             // 匹配任意值的正则表达式
+	Replacement string `yaml:"replacement" toml:"replacement"` // 替换值，默认为 [REDACTED]
+
+	pattern *regexp.Regexp
+}
+
+// 默认配置（与未使用配置文件时的行为一致）
+func DefaultAnalyzerConfig() *AnalyzerConfig {
+	return &AnalyzerConfig{
+		ImportantHeaders: []string{
+			"authorization", "cookie", "content-type", "accept",
+			"user-agent", "referer", "origin", "x-requested-with",
+			"x-csrf-token", "x-api-key", "bearer", "token",
+		},
+		Thresholds: ReportThresholds{
+			APICalls:        1,
+			Parameters:      1,
+			Headers:         5,
+			TemplateHeaders: 1,
+			Statistics:      1,
+		},
+		MaxItems: 20,
+		ContentTypes: []ContentTypeRule{
+			{Type: "EventStream", Match: []string{"event-stream"}},
+			{Type: "NDJSON", Match: []string{"ndjson", "jsonl", "json-seq", "stream+json"}},
+			{Type: "JSON", Match: []string{"json"}},
+			{Type: "HTML", Match: []string{"html"}},
+			{Type: "XML", Match: []string{"xml"}},
+			{Type: "JavaScript", Match: []string{"javascript"}},
+			{Type: "CSS", Match: []string{"css"}},
+			{Type: "Image", Match: []string{"image"}},
+			{Type: "Text", Match: []string{"text"}},
+		},
+		Sections:   append([]string(nil), reportSections...),
+		Naming:     supportedNamingModes[0],
+		OutputMode: supportedOutputModes[0],
+		Watch: WatchConfig{
+			Interval: 2 * time.Second,
+			Settle:   2 * time.Second,
+			Debounce: 5 * time.Second,
+		},
+	}
+}
+
+// 加载配置文件（未指定路径时查找当前目录下的项目配置文件，都不存在时返回默认配置）
+func LoadAnalyzerConfig(path string) (*AnalyzerConfig, error) {
+	if path == "" {
+		for _, name := range projectConfigFileNames {
+			if _, err := os.Stat(name); err == nil {
+				path = name
+				break
+			}
+		}
+		if path == "" {
+			return DefaultAnalyzerConfig(), nil
+		}
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", T("config.read_failed"), err)
+	}
+
+	config := DefaultAnalyzerConfig()
+	config.source = path
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		decoder := yaml.NewDecoder(bytes.NewReader(data))
+		decoder.KnownFields(true)
+		if err := decoder.Decode(config); err != nil && !errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("%s: %w", T("config.parse_failed", path), err)
+		}
+	case ".toml":
+		metadata, err := toml.NewDecoder(bytes.NewReader(data)).Decode(config)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", T("config.parse_failed", path), err)
+		}
+		if undecoded := metadata.Undecoded(); len(undecoded) > 0 {
+			return nil, fmt.Errorf("%s: %s", T("config.parse_failed", path), T("config.unknown_field", undecoded[0].String()))
+		}
+	default:
+		return nil, fmt.Errorf("%s", T("config.unsupported_format", path))
+	}
+
+	if err := config.validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w", T("config.invalid", path), err)
+	}
+	return config, nil
+}
+
+// 校验配置并编译脱敏规则
+func (c *AnalyzerConfig) validate() error {
+	if c.Language != "" {
+		if _, ok := messageCatalogs[strings.ToLower(c.Language)]; !ok {
+			return fmt.Errorf("%s", T("err.unsupported_language", c.Language, strings.Join(supportedLanguages, ", ")))
+		}
+	}
+
+	for _, header := range c.ImportantHeaders {
+		if strings.TrimSpace(header) == "" {
+			return fmt.Errorf("%s", T("config.empty_value", "importantHeaders"))
+		}
+	}
+
+	for _, threshold := range []struct {
+		name  string
+		value int
+	}{
+		{"apiCalls", c.Thresholds.APICalls},
+		{"parameters", c.Thresholds.Parameters},
+		{"headers", c.Thresholds.Headers},
+		{"templateHeaders", c.Thresholds.TemplateHeaders},
+		{"statistics", c.Thresholds.Statistics},
+	} {
+		if threshold.value < 0 {
+			return fmt.Errorf("%s", T("config.negative_threshold", threshold.name))
+		}
+	}
+
+	if c.MaxItems <= 0 {
+		return fmt.Errorf("%s", T("config.invalid_max_items"))
+	}
+
+	for i, rule := range c.ContentTypes {
+		if rule.Type == "" || len(rule.Match) == 0 {
+			return fmt.Errorf("%s", T("config.invalid_content_type", i))
+		}
+		for _, keyword := range rule.Match {
+			if keyword == "" {
+				return fmt.Errorf("%s", T("config.empty_value", fmt.Sprintf("contentTypes[%d].match", i)))
+			}
+		}
+	}
+
+	for i := range c.Redact {
+		rule := &c.Redact[i]
+		targets := 0
+		for _, target := range []string{rule.Header, rule.Param, rule.Cookie, rule.Value} {
+			if target != "" {
+				targets++
+			}
+		}
+		if targets != 1 {
+			return fmt.Errorf("%s", T("config.invalid_redaction", i))
+		}
+		if rule.Value != "" {
+			pattern, err := regexp.Compile(rule.Value)
+			if err != nil {
+				return fmt.Errorf("%s", T("config.invalid_redaction_regex", i, err))
+			}
+			rule.pattern = pattern
+		}
+		if rule.Replacement == "" {
+			rule.Replacement = defaultRedactionReplacement
+		}
+	}
+
+	for i, budget := range c.PageBudgets {
+		if err := budget.validate(i); err != nil {
+			return err
+		}
+	}
+
+	for _, section := range c.Sections {
+		known := false
+		for _, candidate := range reportSections {
+			if section == candidate {
+				known = true
+				break
+			}
+		}
+		if !known {
+			return fmt.Errorf("%s", T("config.unknown_section", section, strings.Join(reportSections, ", ")))
+		}
+	}
+
+	if !containsString(supportedNamingModes, c.Naming) {
+		return fmt.Errorf("%s", T("output.unsupported_naming", c.Naming, strings.Join(supportedNamingModes, ", ")))
+	}
+	if !containsString(supportedOutputModes, c.OutputMode) {
+		return fmt.Errorf("%s", T("output.unsupported_mode", c.OutputMode, strings.Join(supportedOutputModes, ", ")))
+	}
+	return c.Watch.validate()
+}
+
+// 校验 watch 设置
+func (w WatchConfig) validate() error {
+	if w.Interval <= 0 {
+		return fmt.Errorf("%s", T("config.invalid_watch_interval"))
+	}
+	if w.Settle < 0 {
+		return fmt.Errorf("%s", T("config.negative_duration", "watch.settle"))
+	}
+	if w.Debounce < 0 {
+		return fmt.Errorf("%s", T("config.negative_duration", "watch.debounce"))
+	}
+	return nil
+}
+
+// 校验页面预算
+func (b PageBudget) validate(index int) error {
+	if b.Requests < 0 || b.TransferBytes < 0 || b.DOMContentLoaded < 0 || b.OnLoad < 0 || b.RenderBlocking < 0 {
+		return fmt.Errorf("%s", T("config.invalid_page_budget", index))
+	}
+	return nil
+}
+
+// 注册 -config 参数
+func addConfigFlag(flags *flag.FlagSet) *string {
+	return flags.String("config", "", T("flag.config", strings.Join(projectConfigFileNames, ", ")))
+}
+
+// 加载配置并设置过滤条件（命令行中显式指定的 -o、-filter、-exclude-static、-lang、-naming、-output-mode、-proto 优先于配置文件）
+func (ua *UniversalHARAnalyzer) Configure(flags *flag.FlagSet, configPath, filter string, excludeStatic bool) error {
+	config, err := LoadAnalyzerConfig(configPath)
+	if err != nil {
+		return err
+	}
+
+	explicit := make(map[string]bool)
+	flags.Visit(func(f *flag.Flag) {
+		explicit[f.Name] = true
+	})
+
+	if config.Language != "" && !explicit["lang"] {
+		if err := SetLanguage(config.Language); err != nil {
+			return err
+		}
+	}
+	if config.OutputDir != "" && !explicit["o"] {
+		ua.outputDir = config.OutputDir
+	}
+	if !explicit["filter"] {
+		filter = config.Filter
+	}
+	if !explicit["exclude-static"] {
+		excludeStatic = config.ExcludeStatic
+	}
+	ua.config = config
+
+	naming, outputMode := config.Naming, config.OutputMode
+	if explicit["naming"] {
+		naming = flags.Lookup("naming").Value.String()
+	}
+	if explicit["output-mode"] {
+		outputMode = flags.Lookup("output-mode").Value.String()
+	}
+	if err := ua.SetOutputNaming(naming, outputMode); err != nil {
+		return err
+	}
+
+	protoDescriptors := config.ProtoDescriptors
+	if explicit["proto"] {
+		protoDescriptors = strings.Split(flags.Lookup("proto").Value.String(), ",")
+	}
+	if err := ua.SetProtoDescriptors(protoDescriptors); err != nil {
+		return err
+	}
+
+	if err := ua.SetFilter(filter, excludeStatic); err != nil {
+		if !explicit["filter"] && config.source != "" {
+			return fmt.Errorf("%s: %w", T("config.invalid", config.source), err)
+		}
+		return err
+	}
+	return nil
+}
+
+// 当前使用的配置（未加载配置时使用默认配置）
+func (ua *UniversalHARAnalyzer) settings() *AnalyzerConfig {
+	if ua.config == nil {
+		ua.config = DefaultAnalyzerConfig()
+	}
+	return ua.config
+}
+
+// 是否启用指定报告章节
+func (ua *UniversalHARAnalyzer) sectionEnabled(section string) bool {
+	for _, enabled := range ua.settings().Sections {
+		if enabled == section {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,355 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// SQLite导出文件名（har_data.db，非覆盖模式下追加时间戳或版本号）
+const (
+	sqliteExportBaseName = "har"
+	sqliteExportKind     = "data"
+)
+
+// 支持的明细数据导出格式
+var supportedExportFormats = []string{"csv", "ndjson", "sqlite"}
+
+// 明细导出列
+type exportColumn struct {
+	name  string
+	value func(ua *UniversalHARAnalyzer, entry *HAREntry) interface{}
+}
+
+// 所有可导出的列（另外支持 req:<请求头> 和 res:<响应头>）
+var entryExportColumns = []exportColumn{
+	{"timestamp", func(ua *UniversalHARAnalyzer, e *HAREntry) interface{} { return e.StartedDateTime }},
+	{"method", func(ua *UniversalHARAnalyzer, e *HAREntry) interface{} { return e.Request.Method }},
+	{"url", func(ua *UniversalHARAnalyzer, e *HAREntry) interface{} { return ua.redactURL(e.Request.URL) }},
+	{"scheme", func(ua *UniversalHARAnalyzer, e *HAREntry) interface{} { return normalizeURL(e.Request.URL).Scheme }},
+	{"host", func(ua *UniversalHARAnalyzer, e *HAREntry) interface{} { return ua.extractHost(e.Request.URL) }},
+	{"port", func(ua *UniversalHARAnalyzer, e *HAREntry) interface{} { return normalizeURL(e.Request.URL).Port }},
+	{"path", func(ua *UniversalHARAnalyzer, e *HAREntry) interface{} { return ua.extractPath(e.Request.URL) }},
+	{"path_template", func(ua *UniversalHARAnalyzer, e *HAREntry) interface{} {
+		return ua.templatePath(ua.extractPath(e.Request.URL))
+	}},
+	{"http_version", func(ua *UniversalHARAnalyzer, e *HAREntry) interface{} { return e.Request.HTTPVersion }},
+	{"status", func(ua *UniversalHARAnalyzer, e *HAREntry) interface{} { return e.Response.Status }},
+	{"status_text", func(ua *UniversalHARAnalyzer, e *HAREntry) interface{} { return e.Response.StatusText }},
+	{"mime", func(ua *UniversalHARAnalyzer, e *HAREntry) interface{} { return e.Response.Content.MimeType }},
+	{"response_type", func(ua *UniversalHARAnalyzer, e *HAREntry) interface{} {
+		return ua.simplifyContentType(e.Response.Content.MimeType)
+	}},
+	{"request_headers_size", func(ua *UniversalHARAnalyzer, e *HAREntry) interface{} { return e.Request.HeadersSize }},
+	{"request_body_size", func(ua *UniversalHARAnalyzer, e *HAREntry) interface{} { return e.Request.BodySize }},
+	{"response_headers_size", func(ua *UniversalHARAnalyzer, e *HAREntry) interface{} { return e.Response.HeadersSize }},
+	{"response_body_size", func(ua *UniversalHARAnalyzer, e *HAREntry) interface{} { return e.Response.BodySize }},
+	{"content_size", func(ua *UniversalHARAnalyzer, e *HAREntry) interface{} { return e.Response.Content.Size }},
+	{"time", func(ua *UniversalHARAnalyzer, e *HAREntry) interface{} { return e.Time }},
+	{"blocked", func(ua *UniversalHARAnalyzer, e *HAREntry) interface{} { return e.Timings.Blocked }},
+	{"dns", func(ua *UniversalHARAnalyzer, e *HAREntry) interface{} { return e.Timings.DNS }},
+	{"connect", func(ua *UniversalHARAnalyzer, e *HAREntry) interface{} { return e.Timings.Connect }},
+	{"ssl", func(ua *UniversalHARAnalyzer, e *HAREntry) interface{} { return e.Timings.SSL }},
+	{"send", func(ua *UniversalHARAnalyzer, e *HAREntry) interface{} { return e.Timings.Send }},
+	{"wait", func(ua *UniversalHARAnalyzer, e *HAREntry) interface{} { return e.Timings.Wait }},
+	{"receive", func(ua *UniversalHARAnalyzer, e *HAREntry) interface{} { return e.Timings.Receive }},
+	{"page", func(ua *UniversalHARAnalyzer, e *HAREntry) interface{} { return e.Pageref }},
+	{"redirect_url", func(ua *UniversalHARAnalyzer, e *HAREntry) interface{} { return ua.redactURL(e.Response.RedirectURL) }},
+}
+
+// 默认导出列
+var defaultExportColumns = []string{
+	"timestamp", "method", "host", "path_template", "status", "mime",
+	"request_body_size", "response_body_size", "content_size",
+	"time", "blocked", "dns", "connect", "ssl", "send", "wait", "receive", "page",
+}
+
+// 路径模板化规则（按顺序匹配路径中的每一段）
+var pathTemplateRules = []struct {
+	pattern     *regexp.Regexp
+	placeholder string
+}{
+	{regexp.MustCompile(`^[0-9]+$`), "{id}"},
+	{regexp.MustCompile(`^(?i)[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}$`), "{uuid}"},
+	{regexp.MustCompile(`^(?i)[0-9a-f]{16,}$`), "{hash}"},
+	{regexp.MustCompile(`^[A-Za-z0-9_-]{24,}$`), "{token}"},
+}
+
+// 设置明细数据导出格式（逗号分隔）
+func (ua *UniversalHARAnalyzer) SetExportFormats(formats string) error {
+	ua.exportFormats = nil
+	for _, format := range strings.Split(formats, ",") {
+		format = strings.ToLower(strings.TrimSpace(format))
+		if format == "" {
+			continue
+		}
+		supported := false
+		for _, candidate := range supportedExportFormats {
+			if format == candidate {
+				supported = true
+				break
+			}
+		}
+		if !supported {
+			return fmt.Errorf("%s", T("err.unsupported_export", format, strings.Join(supportedExportFormats, ", ")))
+		}
+		ua.addUniqueString(&ua.exportFormats, format)
+	}
+	return nil
+}
+
+// 设置CSV/NDJSON导出列（逗号分隔，为空时使用默认列）
+func (ua *UniversalHARAnalyzer) SetExportColumns(columns string) error {
+	ua.exportColumns = nil
+	for _, column := range strings.Split(columns, ",") {
+		column = strings.TrimSpace(column)
+		if column == "" {
+			continue
+		}
+		if !ua.isExportColumn(column) {
+			var names []string
+			for _, c := range entryExportColumns {
+				names = append(names, c.name)
+			}
+			return fmt.Errorf("%s", T("err.unknown_column", column, strings.Join(names, ", ")))
+		}
+		ua.addUniqueString(&ua.exportColumns, column)
+	}
+	return nil
+}
+
+// 判断是否为有效的导出列
+func (ua *UniversalHARAnalyzer) isExportColumn(column string) bool {
+	if (strings.HasPrefix(column, "req:") || strings.HasPrefix(column, "res:")) && len(column) > 4 {
+		return true
+	}
+	for _, c := range entryExportColumns {
+		if c.name == column {
+			return true
+		}
+	}
+	return false
+}
+
+// 当前使用的导出列
+func (ua *UniversalHARAnalyzer) activeExportColumns() []string {
+	if len(ua.exportColumns) > 0 {
+		return ua.exportColumns
+	}
+	return defaultExportColumns
+}
+
+// 计算条目在指定列上的值
+func (ua *UniversalHARAnalyzer) exportColumnValue(entry *HAREntry, column string) interface{} {
+	headers := entry.Request.Headers
+	if strings.HasPrefix(column, "res:") {
+		headers = entry.Response.Headers
+	}
+	if strings.HasPrefix(column, "req:") || strings.HasPrefix(column, "res:") {
+		var values []string
+		for _, header := range headers {
+			if strings.EqualFold(header.Name, column[4:]) {
+				values = append(values, ua.redactHeader(header.Name, header.Value))
+			}
+		}
+		return strings.Join(values, ", ")
+	}
+
+	for _, c := range entryExportColumns {
+		if c.name == column {
+			return c.value(ua, entry)
+		}
+	}
+	return nil
+}
+
+// 将路径中的ID、UUID、哈希等动态段替换为占位符
+func (ua *UniversalHARAnalyzer) templatePath(path string) string {
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		for _, rule := range pathTemplateRules {
+			if rule.pattern.MatchString(segment) {
+				segments[i] = rule.placeholder
+				break
+			}
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+// 是否启用指定导出格式
+func (ua *UniversalHARAnalyzer) exportEnabled(format string) bool {
+	for _, enabled := range ua.exportFormats {
+		if enabled == format {
+			return true
+		}
+	}
+	return false
+}
+
+// 开始导出（SQLite导出会重建数据库文件）
+func (ua *UniversalHARAnalyzer) beginExports() error {
+	if !ua.exportEnabled("sqlite") {
+		return nil
+	}
+
+	dbFile := ua.sqliteExportPath()
+	if err := os.Remove(dbFile); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("%s: %w", T("err.remove_database"), err)
+	}
+	database, err := OpenHARDatabase(dbFile)
+	if err != nil {
+		return err
+	}
+	ua.database = database
+	return nil
+}
+
+// 导出单个HAR文件的明细数据
+func (ua *UniversalHARAnalyzer) exportEntries(harFile *UniversalHARFile, filePath string) error {
+	baseName, err := ua.outputBaseName(filePath)
+	if err != nil {
+		return err
+	}
+	entries := ua.selectEntries(harFile.Log.Entries)
+
+	if ua.exportEnabled("csv") {
+		csvFile := ua.artifactPath(baseName, "entries", ".csv")
+		if err := ua.writeEntriesCSV(entries, csvFile); err != nil {
+			return fmt.Errorf("%s: %w", T("err.write_export", "CSV"), err)
+		}
+		if err := ua.recordArtifact(csvFile, "entries", filePath); err != nil {
+			return err
+		}
+	}
+
+	if ua.exportEnabled("ndjson") {
+		ndjsonFile := ua.artifactPath(baseName, "entries", ".ndjson")
+		if err := ua.writeEntriesNDJSON(entries, ndjsonFile); err != nil {
+			return fmt.Errorf("%s: %w", T("err.write_export", "NDJSON"), err)
+		}
+		if err := ua.recordArtifact(ndjsonFile, "entries", filePath); err != nil {
+			return err
+		}
+	}
+
+	if ua.database != nil {
+		if err := ua.database.RemoveFile(filePath); err != nil {
+			return fmt.Errorf("%s: %w", T("err.write_export", "SQLite"), err)
+		}
+		if _, err := ua.database.ImportHAR(ua, harFile, filePath); err != nil {
+			return fmt.Errorf("%s: %w", T("err.write_export", "SQLite"), err)
+		}
+	}
+	return nil
+}
+
+// 写入CSV明细
+func (ua *UniversalHARAnalyzer) writeEntriesCSV(entries []HAREntry, path string) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	if err := ua.encodeEntriesCSV(file, entries); err != nil {
+		return err
+	}
+	return file.Close()
+}
+
+// 输出CSV明细
+func (ua *UniversalHARAnalyzer) encodeEntriesCSV(w io.Writer, entries []HAREntry) error {
+	columns := ua.activeExportColumns()
+	writer := csv.NewWriter(w)
+	writer.Write(columns)
+	for i := range entries {
+		row := make([]string, len(columns))
+		for j, column := range columns {
+			row[j] = formatExportValue(ua.exportColumnValue(&entries[i], column))
+		}
+		writer.Write(row)
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// 写入NDJSON明细
+func (ua *UniversalHARAnalyzer) writeEntriesNDJSON(entries []HAREntry, path string) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	if err := ua.encodeEntriesNDJSON(file, entries); err != nil {
+		return err
+	}
+	return file.Close()
+}
+
+// 输出NDJSON明细（每行一个JSON对象，字段顺序与列顺序一致）
+func (ua *UniversalHARAnalyzer) encodeEntriesNDJSON(w io.Writer, entries []HAREntry) error {
+	columns := ua.activeExportColumns()
+	var line strings.Builder
+	for i := range entries {
+		line.Reset()
+		line.WriteString("{")
+		for j, column := range columns {
+			key, _ := json.Marshal(column)
+			value, err := json.Marshal(ua.exportColumnValue(&entries[i], column))
+			if err != nil {
+				return err
+			}
+			if j > 0 {
+				line.WriteString(",")
+			}
+			line.Write(key)
+			line.WriteString(":")
+			line.Write(value)
+		}
+		line.WriteString("}\n")
+		if _, err := io.WriteString(w, line.String()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// 格式化导出值
+func formatExportValue(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case int:
+		return strconv.Itoa(v)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	}
+	return fmt.Sprint(value)
+}
+
+// 结束导出
+func (ua *UniversalHARAnalyzer) finishExports() {
+	if ua.database != nil {
+		ua.database.Close()
+		ua.database = nil
+		dbFile := ua.sqliteExportPath()
+		if err := ua.recordArtifact(dbFile, "sqlite", ""); err != nil {
+			fmt.Println("⚠️ " + T("output.manifest_failed", err))
+		}
+		fmt.Println("🗄️ " + T("export.sqlite_file", dbFile))
+	}
+}
+
+// SQLite导出文件路径
+func (ua *UniversalHARAnalyzer) sqliteExportPath() string {
+	return ua.artifactPath(sqliteExportBaseName, sqliteExportKind, ".db")
+}
@@ -0,0 +1,507 @@
+package main
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// 请求过滤器，在统计之前筛选HAR条目
+//
+// 表达式示例:
+//
+//	host ~ "api." && status >= 400 && method == "POST" && mime contains "json" && time > 500ms
+//
+// 支持的字段:
+//   - 字符串: host, path, url, method, mime, type, page, header.<名称>
+//   - 数值: status, time（毫秒，可写作 500ms/1.5s/2m）, size（字节，可写作 10kb/1mb）
+//   - 布尔: static（图片、CSS、JS、字体等静态资源）
+//
+// 支持的运算符: == != < <= > >= ~（正则匹配） !~ contains，以及 && || ! 和括号
+type EntryFilter struct {
+	source string
+	root   filterNode
+}
+
+// 过滤表达式语法树节点
+type filterNode interface {
+	eval(entry *HAREntry) bool
+}
+
+type filterAnd struct{ left, right filterNode }
+type filterOr struct{ left, right filterNode }
+type filterNot struct{ operand filterNode }
+type filterStatic struct{}
+
+type filterCompare struct {
+	analyzer *UniversalHARAnalyzer // 用于计算 host、type 等派生字段
+	field    string
+	op       string
+	text     string
+	number   float64
+	pattern  *regexp.Regexp
+}
+
+func (n filterAnd) eval(entry *HAREntry) bool  { return n.left.eval(entry) && n.right.eval(entry) }
+func (n filterOr) eval(entry *HAREntry) bool   { return n.left.eval(entry) || n.right.eval(entry) }
+func (n filterNot) eval(entry *HAREntry) bool  { return !n.operand.eval(entry) }
+func (filterStatic) eval(entry *HAREntry) bool { return isStaticResource(entry) }
+
+// 字段类型
+const (
+	filterFieldString = iota
+	filterFieldNumber
+)
+
+var filterFields = map[string]int{
+	"host":   filterFieldString,
+	"scheme": filterFieldString,
+	"path":   filterFieldString,
+	"url":    filterFieldString,
+	"method": filterFieldString,
+	"mime":   filterFieldString,
+	"type":   filterFieldString,
+	"page":   filterFieldString,
+	"port":   filterFieldNumber,
+	"status": filterFieldNumber,
+	"time":   filterFieldNumber,
+	"size":   filterFieldNumber,
+}
+
+// 数值单位换算（时间单位换算为毫秒，大小单位换算为字节）
+var filterTimeUnits = map[string]float64{"ms": 1, "s": 1000, "m": 60000}
+var filterSizeUnits = map[string]float64{"b": 1, "kb": 1024, "mb": 1024 * 1024, "gb": 1024 * 1024 * 1024}
+
+// 使用指定分析器的配置解析过滤表达式
+func parseEntryFilter(source string, analyzer *UniversalHARAnalyzer) (*EntryFilter, error) {
+	p := &filterParser{source: source, analyzer: analyzer}
+	if err := p.tokenize(); err != nil {
+		return nil, err
+	}
+	if len(p.tokens) == 0 {
+		return nil, fmt.Errorf("%s", T("filter.empty"))
+	}
+
+	root, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if p.pos < len(p.tokens) {
+		return nil, p.syntaxError(p.tokens[p.pos], "filter.trailing", p.tokens[p.pos].text)
+	}
+
+	return &EntryFilter{source: source, root: root}, nil
+}
+
+// 组合过滤条件（任一参数为nil时返回另一个）
+func CombineEntryFilters(a, b *EntryFilter) *EntryFilter {
+	if a == nil {
+		return b
+	}
+	if b == nil {
+		return a
+	}
+	return &EntryFilter{
+		source: fmt.Sprintf("(%s) && (%s)", a.source, b.source),
+		root:   filterAnd{a.root, b.root},
+	}
+}
+
+// 排除静态资源的过滤器
+func ExcludeStaticFilter() *EntryFilter {
+	return &EntryFilter{source: "!static", root: filterNot{filterStatic{}}}
+}
+
+// 判断条目是否满足过滤条件
+func (f *EntryFilter) Match(entry *HAREntry) bool {
+	if f == nil {
+		return true
+	}
+	return f.root.eval(entry)
+}
+
+// 过滤表达式原文
+func (f *EntryFilter) String() string {
+	if f == nil {
+		return ""
+	}
+	return f.source
+}
+
+// 取字段值
+func (n filterCompare) value(entry *HAREntry) (string, float64) {
+	analyzer := n.analyzer
+
+	switch n.field {
+	case "host":
+		return strings.ToLower(analyzer.extractHost(entry.Request.URL)), 0
+	case "scheme":
+		return normalizeURL(entry.Request.URL).Scheme, 0
+	case "path":
+		return analyzer.extractPath(entry.Request.URL), 0
+	case "port":
+		return "", float64(normalizeURL(entry.Request.URL).Port)
+	case "url":
+		return entry.Request.URL, 0
+	case "method":
+		return strings.ToUpper(entry.Request.Method), 0
+	case "mime":
+		return entry.Response.Content.MimeType, 0
+	case "type":
+		return analyzer.simplifyContentType(entry.Response.Content.MimeType), 0
+	case "page":
+		return entry.Pageref, 0
+	case "status":
+		return "", float64(entry.Response.Status)
+	case "time":
+		return "", entry.Time
+	case "size":
+		return "", entry.Response.Content.Size
+	}
+
+	// header.<名称>
+	name := strings.TrimPrefix(n.field, "header.")
+	for _, header := range entry.Request.Headers {
+		if strings.EqualFold(header.Name, name) {
+			return header.Value, 0
+		}
+	}
+	return "", 0
+}
+
+func (n filterCompare) eval(entry *HAREntry) bool {
+	text, number := n.value(entry)
+
+	if fieldType, exists := filterFields[n.field]; exists && fieldType == filterFieldNumber {
+		switch n.op {
+		case "==":
+			return number == n.number
+		case "!=":
+			return number != n.number
+		case "<":
+			return number < n.number
+		case "<=":
+			return number <= n.number
+		case ">":
+			return number > n.number
+		case ">=":
+			return number >= n.number
+		}
+		return false
+	}
+
+	switch n.op {
+	case "==":
+		return text == n.text
+	case "!=":
+		return text != n.text
+	case "<":
+		return text < n.text
+	case "<=":
+		return text <= n.text
+	case ">":
+		return text > n.text
+	case ">=":
+		return text >= n.text
+	case "~":
+		return n.pattern.MatchString(text)
+	case "!~":
+		return !n.pattern.MatchString(text)
+	case "contains":
+		return strings.Contains(strings.ToLower(text), strings.ToLower(n.text))
+	}
+	return false
+}
+
+// 判断是否为静态资源（按 resourceKind 分类的样式、脚本、图片、字体、音视频，以及 source map）
+func isStaticResource(entry *HAREntry) bool {
+	switch resourceKind(entry) {
+	case "stylesheet", "script", "image", "font", "media":
+		return true
+	}
+	return strings.ToLower(path.Ext(normalizeURL(entry.Request.URL).Path)) == ".map"
+}
+
+// 词法单元
+type filterToken struct {
+	kind string // ident, string, number, op, (, )
+	text string
+	pos  int
+}
+
+// 过滤表达式解析器
+type filterParser struct {
+	analyzer *UniversalHARAnalyzer
+	source   string
+	tokens   []filterToken
+	pos      int
+}
+
+func (p *filterParser) syntaxError(tok filterToken, key string, args ...interface{}) error {
+	return fmt.Errorf("%s", T("filter.syntax_error", tok.pos+1, T(key, args...)))
+}
+
+// 词法分析
+func (p *filterParser) tokenize() error {
+	src := []rune(p.source)
+	for i := 0; i < len(src); {
+		r := src[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '(' || r == ')':
+			p.tokens = append(p.tokens, filterToken{kind: string(r), text: string(r), pos: i})
+			i++
+		case r == '"' || r == '\'':
+			start := i
+			var text strings.Builder
+			i++
+			for ; i < len(src) && src[i] != r; i++ {
+				if src[i] == '\\' && i+1 < len(src) {
+					i++
+				}
+				text.WriteRune(src[i])
+			}
+			if i >= len(src) {
+				return p.syntaxError(filterToken{pos: start}, "filter.unterminated_string")
+			}
+			i++
+			p.tokens = append(p.tokens, filterToken{kind: "string", text: text.String(), pos: start})
+		case strings.ContainsRune("=!<>~&|", r):
+			start := i
+			op := string(r)
+			if i+1 < len(src) {
+				two := string(src[i : i+2])
+				switch two {
+				case "==", "!=", "<=", ">=", "!~", "&&", "||":
+					op = two
+				}
+			}
+			if op == "=" || op == "&" || op == "|" {
+				return p.syntaxError(filterToken{pos: start}, "filter.unknown_operator", op)
+			}
+			i += len([]rune(op))
+			p.tokens = append(p.tokens, filterToken{kind: "op", text: op, pos: start})
+		case unicode.IsDigit(r) || (r == '.' && i+1 < len(src) && unicode.IsDigit(src[i+1])):
+			start := i
+			for i < len(src) && (unicode.IsDigit(src[i]) || src[i] == '.' || unicode.IsLetter(src[i])) {
+				i++
+			}
+			p.tokens = append(p.tokens, filterToken{kind: "number", text: string(src[start:i]), pos: start})
+		case unicode.IsLetter(r) || r == '_':
+			start := i
+			for i < len(src) && (unicode.IsLetter(src[i]) || unicode.IsDigit(src[i]) || strings.ContainsRune("_.-", src[i])) {
+				i++
+			}
+			text := string(src[start:i])
+			switch strings.ToLower(text) {
+			case "and":
+				p.tokens = append(p.tokens, filterToken{kind: "op", text: "&&", pos: start})
+			case "or":
+				p.tokens = append(p.tokens, filterToken{kind: "op", text: "||", pos: start})
+			case "not":
+				p.tokens = append(p.tokens, filterToken{kind: "op", text: "!", pos: start})
+			case "contains":
+				p.tokens = append(p.tokens, filterToken{kind: "op", text: "contains", pos: start})
+			case "matches":
+				p.tokens = append(p.tokens, filterToken{kind: "op", text: "~", pos: start})
+			default:
+				p.tokens = append(p.tokens, filterToken{kind: "ident", text: text, pos: start})
+			}
+		default:
+			return p.syntaxError(filterToken{pos: i}, "filter.unexpected_char", r)
+		}
+	}
+	return nil
+}
+
+func (p *filterParser) peek() (filterToken, bool) {
+	if p.pos >= len(p.tokens) {
+		return filterToken{}, false
+	}
+	return p.tokens[p.pos], true
+}
+
+// 表达式末尾的位置（用于报错）
+func (p *filterParser) endToken() filterToken {
+	return filterToken{pos: len([]rune(p.source))}
+}
+
+// or := and ("||" and)*
+func (p *filterParser) parseOr() (filterNode, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		tok, ok := p.peek()
+		if !ok || tok.kind != "op" || tok.text != "||" {
+			return left, nil
+		}
+		p.pos++
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = filterOr{left, right}
+	}
+}
+
+// and := unary ("&&" unary)*
+func (p *filterParser) parseAnd() (filterNode, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		tok, ok := p.peek()
+		if !ok || tok.kind != "op" || tok.text != "&&" {
+			return left, nil
+		}
+		p.pos++
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = filterAnd{left, right}
+	}
+}
+
+// unary := "!" unary | "(" or ")" | comparison
+func (p *filterParser) parseUnary() (filterNode, error) {
+	tok, ok := p.peek()
+	if !ok {
+		return nil, p.syntaxError(p.endToken(), "filter.incomplete")
+	}
+
+	if tok.kind == "op" && tok.text == "!" {
+		p.pos++
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return filterNot{operand}, nil
+	}
+
+	if tok.kind == "(" {
+		p.pos++
+		node, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		closing, ok := p.peek()
+		if !ok || closing.kind != ")" {
+			return nil, p.syntaxError(tok, "filter.unclosed_paren")
+		}
+		p.pos++
+		return node, nil
+	}
+
+	return p.parseComparison()
+}
+
+// comparison := field op value | "static"
+func (p *filterParser) parseComparison() (filterNode, error) {
+	fieldTok, _ := p.peek()
+	if fieldTok.kind != "ident" {
+		return nil, p.syntaxError(fieldTok, "filter.expected_field", fieldTok.text)
+	}
+	p.pos++
+
+	field := strings.ToLower(fieldTok.text)
+	if field == "static" {
+		return filterStatic{}, nil
+	}
+
+	fieldType, known := filterFields[field]
+	if !known {
+		if !strings.HasPrefix(field, "header.") || len(field) == len("header.") {
+			return nil, p.syntaxError(fieldTok, "filter.unknown_field", fieldTok.text)
+		}
+		fieldType = filterFieldString
+	}
+
+	opTok, ok := p.peek()
+	if !ok || opTok.kind != "op" || opTok.text == "&&" || opTok.text == "||" || opTok.text == "!" {
+		return nil, p.syntaxError(fieldTok, "filter.missing_operator", fieldTok.text)
+	}
+	p.pos++
+
+	valueTok, ok := p.peek()
+	if !ok {
+		return nil, p.syntaxError(p.endToken(), "filter.missing_value", opTok.text)
+	}
+	p.pos++
+
+	node := filterCompare{analyzer: p.analyzer, field: field, op: opTok.text}
+
+	if fieldType == filterFieldNumber {
+		if opTok.text == "~" || opTok.text == "!~" || opTok.text == "contains" {
+			return nil, p.syntaxError(opTok, "filter.numeric_operator", field, opTok.text)
+		}
+		if valueTok.kind != "number" {
+			return nil, p.syntaxError(valueTok, "filter.expected_number", field, valueTok.text)
+		}
+		number, err := p.parseNumber(field, valueTok)
+		if err != nil {
+			return nil, err
+		}
+		node.number = number
+		return node, nil
+	}
+
+	if valueTok.kind != "string" && valueTok.kind != "ident" && valueTok.kind != "number" {
+		return nil, p.syntaxError(valueTok, "filter.expected_string", field, valueTok.text)
+	}
+	node.text = valueTok.text
+	switch field {
+	case "method":
+		node.text = strings.ToUpper(node.text)
+	case "host", "scheme":
+		node.text = strings.ToLower(node.text)
+	}
+
+	if opTok.text == "~" || opTok.text == "!~" {
+		pattern, err := regexp.Compile(valueTok.text)
+		if err != nil {
+			return nil, p.syntaxError(valueTok, "filter.invalid_regex", err)
+		}
+		node.pattern = pattern
+	}
+	return node, nil
+}
+
+// 解析带单位的数值
+func (p *filterParser) parseNumber(field string, tok filterToken) (float64, error) {
+	text := strings.ToLower(tok.text)
+	split := strings.IndexFunc(text, unicode.IsLetter)
+	if split < 0 {
+		split = len(text)
+	}
+
+	number, err := strconv.ParseFloat(text[:split], 64)
+	if err != nil {
+		return 0, p.syntaxError(tok, "filter.invalid_number", tok.text)
+	}
+
+	unit := text[split:]
+	if unit == "" {
+		return number, nil
+	}
+
+	var units map[string]float64
+	switch field {
+	case "time":
+		units = filterTimeUnits
+	case "size":
+		units = filterSizeUnits
+	}
+	factor, ok := units[unit]
+	if !ok {
+		return 0, p.syntaxError(tok, "filter.invalid_unit", field, unit)
+	}
+	return number * factor, nil
+}
@@ -0,0 +1,141 @@
+package main
+
+import (
+	"testing"
+)
+
+func filterTestEntry(method, url string, status int, mimeType string, elapsed, size float64) *HAREntry {
+	entry := &HAREntry{Time: elapsed}
+	entry.Request.Method = method
+	entry.Request.URL = url
+	entry.Response.Status = status
+	entry.Response.Content.MimeType = mimeType
+	entry.Response.Content.Size = size
+	return entry
+}
+
+func TestParseEntryFilter(t *testing.T) {
+	apiError := filterTestEntry("POST", "https://api.example.com/v1/orders", 500, "application/json; charset=utf-8", 600, 10240)
+	page := filterTestEntry("GET", "https://www.example.com/index.html", 200, "text/html", 120, 1536)
+	notFound := filterTestEntry("POST", "http://api.example.com:8080/missing", 404, "text/plain", 30, 0)
+
+	tests := []struct {
+		name   string
+		filter string
+		want   []bool // apiError、page、notFound 是否匹配
+	}{
+		{"request example", `host ~ "api." && status >= 400 && method == "POST" && mime contains "json" && time > 500ms`, []bool{true, false, false}},
+		{"and binds tighter than or", `status == 200 || status == 404 && method == "GET"`, []bool{false, true, false}},
+		{"parentheses", `(status == 200 || status == 404) && method == "POST"`, []bool{false, false, true}},
+		{"not binds tighter than and", `!status == 200 && method == "GET"`, []bool{false, false, false}},
+		{"not before parentheses", `!(status == 200 && method == "GET")`, []bool{true, false, true}},
+		{"double not", `!!method == "GET"`, []bool{false, true, false}},
+		{"keyword operators", `not status >= 500 and (host == "API.example.com" or port == 8080)`, []bool{false, false, true}},
+		{"method case insensitive", `method == "post"`, []bool{true, false, true}},
+		{"regex not match", `path !~ "^/v1/"`, []bool{false, true, true}},
+		{"header field missing", `header.authorization == ""`, []bool{true, true, true}},
+		{"static", `static`, []bool{false, false, false}},
+		{"milliseconds", `time >= 120ms`, []bool{true, true, false}},
+		{"plain number is milliseconds", `time > 100`, []bool{true, true, false}},
+		{"seconds", `time > 0.5s`, []bool{true, false, false}},
+		{"minutes", `time < 0.01m`, []bool{false, true, true}},
+		{"kilobytes", `size == 1.5kb`, []bool{false, true, false}},
+		{"kilobytes uppercase", `size >= 10KB`, []bool{true, false, false}},
+		{"megabytes", `size < 1mb && size > 0b`, []bool{true, true, false}},
+	}
+
+	analyzer := NewUniversalHARAnalyzer()
+	entries := []*HAREntry{apiError, page, notFound}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filter, err := parseEntryFilter(tt.filter, analyzer)
+			if err != nil {
+				t.Fatalf("parseEntryFilter(%q) error: %v", tt.filter, err)
+			}
+			for i, entry := range entries {
+				if got := filter.Match(entry); got != tt.want[i] {
+					t.Errorf("Match(%s %s) = %v, want %v", entry.Request.Method, entry.Request.URL, got, tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestParseEntryFilterErrors(t *testing.T) {
+	defer func() { currentLanguage = "zh" }()
+	if err := SetLanguage("en"); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name   string
+		filter string
+		want   string
+	}{
+		{"empty", "  ", "filter expression is empty"},
+		{"unterminated string", `host ~ "api`, "filter syntax error near character 8: unterminated string"},
+		{"single equals", `host = "a"`, `filter syntax error near character 6: unknown operator "="`},
+		{"single ampersand", `status == 200 & method == "GET"`, `filter syntax error near character 15: unknown operator "&"`},
+		{"unexpected character", `host == "a" #`, "filter syntax error near character 13: unexpected character '#'"},
+		{"missing value", `status >= `, `filter syntax error near character 11: missing value after operator ">="`},
+		{"dangling and", `status >= 400 &&`, "filter syntax error near character 17: incomplete expression"},
+		{"unclosed parenthesis", `(status == 200`, "filter syntax error near character 1: unclosed parenthesis"},
+		{"trailing token", `status == 200 method == "GET"`, `filter syntax error near character 15: unexpected "method"`},
+		{"expected field", `== 200`, `filter syntax error near character 1: expected a field name, got "=="`},
+		{"unknown field", `foo == 1`, `filter syntax error near character 1: unknown field "foo"`},
+		{"empty header name", `header. == "x"`, `filter syntax error near character 1: unknown field "header."`},
+		{"missing operator", `status 200`, `filter syntax error near character 1: missing comparison operator after field "status"`},
+		{"regex on number", `status ~ "5.."`, `filter syntax error near character 8: numeric field "status" does not support operator "~"`},
+		{"string for number", `status == "ok"`, `filter syntax error near character 11: field "status" expects a number, got "ok"`},
+		{"invalid number", `time > 1.2.3s`, `filter syntax error near character 8: invalid number "1.2.3s"`},
+		{"unknown time unit", `time > 5h`, `filter syntax error near character 8: field "time" does not support unit "h"`},
+		{"size unit for time", `time > 5kb`, `filter syntax error near character 8: field "time" does not support unit "kb"`},
+		{"time unit for size", `size > 10ms`, `filter syntax error near character 8: field "size" does not support unit "ms"`},
+		{"unit on unitless field", `status == 200ms`, `filter syntax error near character 11: field "status" does not support unit "ms"`},
+		{"invalid regex", `host ~ "("`, "filter syntax error near character 8: invalid regular expression: error parsing regexp: missing closing ): `(`"},
+		{"position counts characters", `page == "首页" && time > x`, `filter syntax error near character 24: field "time" expects a number, got "x"`},
+	}
+
+	analyzer := NewUniversalHARAnalyzer()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseEntryFilter(tt.filter, analyzer)
+			if err == nil {
+				t.Fatalf("parseEntryFilter(%q) succeeded, want error %q", tt.filter, tt.want)
+			}
+			if err.Error() != tt.want {
+				t.Errorf("parseEntryFilter(%q) error = %q, want %q", tt.filter, err.Error(), tt.want)
+			}
+		})
+	}
+}
+
+func TestIsStaticResource(t *testing.T) {
+	tests := []struct {
+		name         string
+		url          string
+		mimeType     string
+		resourceType string
+		want         bool
+	}{
+		{"script by mime", "https://cdn.example.com/app", "application/javascript", "", true},
+		{"script by extension", "https://cdn.example.com/app.js", "", "", true},
+		{"json served from js url", "https://api.example.com/config.js", "application/json", "", false},
+		{"html served from css url", "https://example.com/theme.css", "text/html", "", false},
+		{"generic mime falls back to extension", "https://cdn.example.com/logo.png", "application/octet-stream", "", true},
+		{"font by extension", "https://cdn.example.com/a.woff2", "text/plain", "", true},
+		{"source map", "https://cdn.example.com/app.js.map", "application/json", "", true},
+		{"resource type wins", "https://api.example.com/data.json", "application/json", "script", true},
+		{"api call", "https://api.example.com/v1/users", "application/json", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entry := filterTestEntry("GET", tt.url, 200, tt.mimeType, 0, 0)
+			entry.ResourceType = tt.resourceType
+			if got := isStaticResource(entry); got != tt.want {
+				t.Errorf("isStaticResource(%s, %q) = %v, want %v", tt.url, tt.mimeType, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,369 @@
+package main
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"flag"
+	"fmt"
+	"net/textproto"
+	"sort"
+	"strconv"
+	"strings"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// 每个方法最多记录的不同状态数
+const maxGRPCStatuses = 10
+
+// 示例消息最多显示的行数
+const maxProtoExampleLines = 40
+
+// gRPC状态码名称
+var grpcStatusNames = []string{
+	"OK", "CANCELLED", "UNKNOWN", "INVALID_ARGUMENT", "DEADLINE_EXCEEDED", "NOT_FOUND", "ALREADY_EXISTS",
+	"PERMISSION_DENIED", "RESOURCE_EXHAUSTED", "FAILED_PRECONDITION", "ABORTED", "OUT_OF_RANGE",
+	"UNIMPLEMENTED", "INTERNAL", "UNAVAILABLE", "DATA_LOSS", "UNAUTHENTICATED",
+}
+
+// gRPC-Web / protobuf 分析结果
+type GRPCAnalysis struct {
+	Requests    int          `json:"requests"`    // gRPC-Web和protobuf请求数
+	Descriptors bool         `json:"descriptors"` // 是否使用了 .proto 描述符集合
+	Methods     []GRPCMethod `json:"methods"`     // 按服务和方法分组的统计
+}
+
+// 单个gRPC方法（或protobuf接口）的统计
+type GRPCMethod struct {
+	Service         string         `json:"service,omitempty"` // 完整服务名（package.Service），非gRPC路径时为空
+	Method          string         `json:"method"`            // 方法名，非gRPC路径时为 HTTP方法+路径
+	Host            string         `json:"host"`
+	Protocol        string         `json:"protocol"` // grpc-web、grpc-web-text、grpc、protobuf
+	Calls           int            `json:"calls"`
+	Errors          int            `json:"errors"`               // grpc-status 不为0或HTTP请求失败的次数
+	Statuses        map[string]int `json:"statuses,omitempty"`   // grpc-status（及 grpc-message）及出现次数
+	RequestBytes    int            `json:"requestBytes"`         // 请求消息总字节数
+	ResponseBytes   int            `json:"responseBytes"`        // 响应消息总字节数
+	Messages        int            `json:"messages"`             // 响应中的消息数（服务端流可能有多个）
+	Schema          bool           `json:"schema"`               // 是否按描述符解码
+	Compressed      bool           `json:"compressed,omitempty"` // 消息经过压缩（不解码示例）
+	RequestExample  string         `json:"requestExample,omitempty"`
+	ResponseExample string         `json:"responseExample,omitempty"`
+	Latency         LatencyStats   `json:"latency"`
+
+	durations []float64
+}
+
+// gRPC-Web 响应体中的帧
+type grpcWebFrames struct {
+	messages   [][]byte
+	trailers   map[string]string
+	compressed bool
+}
+
+// 注册 -proto 参数
+func addProtoFlag(flags *flag.FlagSet) {
+	flags.String("proto", "", T("flag.proto"))
+}
+
+// 设置用于解码的描述符集合文件（为空时只做无模式解码）
+func (ua *UniversalHARAnalyzer) SetProtoDescriptors(paths []string) error {
+	ua.protoDescriptors = nil
+	var files []string
+	for _, path := range paths {
+		if path = strings.TrimSpace(path); path != "" {
+			files = append(files, path)
+		}
+	}
+	if len(files) == 0 {
+		return nil
+	}
+	descriptors, err := loadProtoDescriptors(files)
+	if err != nil {
+		return err
+	}
+	ua.protoDescriptors = descriptors
+	return nil
+}
+
+// 请求或响应使用的gRPC/protobuf协议（不是时返回空字符串）
+func grpcProtocol(mimeType string) string {
+	mimeType = strings.ToLower(mimeType)
+	switch {
+	case strings.Contains(mimeType, "grpc-web-text"):
+		return "grpc-web-text"
+	case strings.Contains(mimeType, "grpc-web"):
+		return "grpc-web"
+	case strings.Contains(mimeType, "application/grpc"):
+		return "grpc"
+	case strings.Contains(mimeType, "protobuf"):
+		return "protobuf"
+	}
+	return ""
+}
+
+// 从路径中解析服务和方法（/package.Service/Method）
+func grpcServiceMethod(path string) (string, string, bool) {
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+	if len(parts) < 2 {
+		return "", "", false
+	}
+	service, method := parts[len(parts)-2], parts[len(parts)-1]
+	if service == "" || method == "" || !strings.Contains(service, ".") {
+		return "", "", false
+	}
+	return service, method, true
+}
+
+// 分析所有gRPC-Web和protobuf请求（没有时返回nil）
+func (ua *UniversalHARAnalyzer) analyzeGRPC(entries []HAREntry) *GRPCAnalysis {
+	analysis := &GRPCAnalysis{Descriptors: ua.protoDescriptors != nil}
+	methods := make(map[string]*GRPCMethod)
+	var order []string
+
+	for i := range entries {
+		entry := &entries[i]
+		protocol := grpcProtocol(entry.Request.PostData.MimeType)
+		if protocol == "" {
+			protocol = grpcProtocol(headerValue(entry.Request.Headers, "Content-Type"))
+		}
+		if protocol == "" {
+			protocol = grpcProtocol(entry.Response.Content.MimeType)
+		}
+		if protocol == "" {
+			continue
+		}
+		analysis.Requests++
+
+		normalized := normalizeURL(entry.Request.URL)
+		service, methodName, isGRPC := grpcServiceMethod(normalized.Path)
+		if !isGRPC {
+			methodName = entry.Request.Method + " " + normalized.Path
+		}
+		key := normalized.Authority() + " " + service + "/" + methodName
+		method, exists := methods[key]
+		if !exists {
+			method = &GRPCMethod{Service: service, Method: methodName, Host: normalized.Authority(), Protocol: protocol}
+			methods[key] = method
+			order = append(order, key)
+		}
+		method.Calls++
+		method.durations = append(method.durations, roundMillis(entry.Time))
+
+		// 请求和响应消息
+		requestBody := grpcBody([]byte(entry.Request.PostData.Text), protocol)
+		responseBody := grpcBody([]byte(responseBodyText(entry)), protocol)
+		var requests, responses [][]byte
+		var trailers map[string]string
+		if protocol == "protobuf" {
+			requests, responses = nonEmpty(requestBody), nonEmpty(responseBody)
+		} else {
+			requestFrames := parseGRPCWebFrames(requestBody)
+			responseFrames := parseGRPCWebFrames(responseBody)
+			requests, responses, trailers = requestFrames.messages, responseFrames.messages, responseFrames.trailers
+			if requestFrames.compressed || responseFrames.compressed {
+				method.Compressed = true
+			}
+		}
+		for _, message := range requests {
+			method.RequestBytes += len(message)
+		}
+		for _, message := range responses {
+			method.ResponseBytes += len(message)
+		}
+		method.Messages += len(responses)
+
+		// 状态（trailer帧优先，其次是响应头，trailers-only响应只有响应头）
+		status := trailers["grpc-status"]
+		message := trailers["grpc-message"]
+		if status == "" {
+			status = headerValue(entry.Response.Headers, "grpc-status")
+			message = headerValue(entry.Response.Headers, "grpc-message")
+		}
+		failed := entry.Response.Status == 0 || entry.Response.Status >= 400
+		if status != "" || failed {
+			if method.Statuses == nil {
+				method.Statuses = make(map[string]int)
+			}
+			description := grpcStatusDescription(status, message)
+			if status == "" {
+				description = strings.TrimSpace(fmt.Sprintf("HTTP %d %s", entry.Response.Status, entry.Response.StatusText))
+			}
+			description = ua.redactValue(description)
+			if _, seen := method.Statuses[description]; seen || len(method.Statuses) < maxGRPCStatuses {
+				method.Statuses[description]++
+			}
+			if failed || (status != "" && status != "0") {
+				method.Errors++
+			}
+		}
+
+		// 示例消息（有描述符时按类型解码）
+		var descriptor protoreflect.MethodDescriptor
+		if isGRPC {
+			descriptor, method.Schema = ua.protoDescriptors.method(service, methodName)
+		}
+		if method.Compressed {
+			continue
+		}
+		if method.RequestExample == "" && len(requests) > 0 {
+			method.RequestExample = ua.protoExample(requests[0], descriptor, true)
+		}
+		if method.ResponseExample == "" && len(responses) > 0 {
+			method.ResponseExample = ua.protoExample(responses[0], descriptor, false)
+		}
+	}
+	if analysis.Requests == 0 {
+		return nil
+	}
+
+	for _, key := range order {
+		method := methods[key]
+		method.Latency = computeLatencyStats(method.durations)
+		analysis.Methods = append(analysis.Methods, *method)
+	}
+	sort.SliceStable(analysis.Methods, func(i, j int) bool {
+		return analysis.Methods[i].Calls > analysis.Methods[j].Calls
+	})
+	return analysis
+}
+
+// 报告中显示的方法名（package.Service/Method）
+func (m GRPCMethod) FullName() string {
+	if m.Service == "" {
+		return m.Method
+	}
+	return m.Service + "/" + m.Method
+}
+
+// gRPC状态的描述（5 NOT_FOUND: user not found）
+func grpcStatusDescription(status, message string) string {
+	description := status
+	if code, err := strconv.Atoi(status); err == nil && code >= 0 && code < len(grpcStatusNames) {
+		description += " " + grpcStatusNames[code]
+	}
+	if message != "" {
+		// grpc-message 使用百分号编码
+		if decoded, err := decodePercentEncoding(message); err == nil {
+			message = decoded
+		}
+		description += ": " + message
+	}
+	return description
+}
+
+// 解码 grpc-message 中的百分号编码
+func decodePercentEncoding(text string) (string, error) {
+	var decoded strings.Builder
+	for i := 0; i < len(text); i++ {
+		if text[i] == '%' && i+2 < len(text) && isHexDigit(text[i+1]) && isHexDigit(text[i+2]) {
+			value, err := strconv.ParseUint(text[i+1:i+3], 16, 8)
+			if err != nil {
+				return "", err
+			}
+			decoded.WriteByte(byte(value))
+			i += 2
+			continue
+		}
+		decoded.WriteByte(text[i])
+	}
+	return decoded.String(), nil
+}
+
+// 解码后的示例消息（已脱敏、过长时截断）
+func (ua *UniversalHARAnalyzer) protoExample(data []byte, method protoreflect.MethodDescriptor, request bool) string {
+	text := ""
+	if method != nil {
+		descriptor := method.Output()
+		if request {
+			descriptor = method.Input()
+		}
+		if decoded, err := decodeProtoMessage(data, descriptor); err == nil {
+			text = decoded
+		}
+	}
+	if text == "" {
+		fields, ok := decodeProtoWire(data, 0)
+		if !ok {
+			return formatProtoBytes(data)
+		}
+		text = strings.TrimSuffix(formatProtoFields(fields, ""), "\n")
+	}
+
+	lines := strings.Split(ua.redactValue(text), "\n")
+	if len(lines) > maxProtoExampleLines {
+		lines = append(lines[:maxProtoExampleLines], "…")
+	}
+	return strings.Join(lines, "\n")
+}
+
+// 按协议还原请求体或响应体的字节（grpc-web-text 为base64）
+func grpcBody(data []byte, protocol string) []byte {
+	if protocol != "grpc-web-text" || len(data) == 0 {
+		return data
+	}
+	// 流式响应可能是多段各自补齐的base64
+	var decoded []byte
+	text := strings.TrimSpace(string(data))
+	for text != "" {
+		end := strings.IndexByte(text, '=')
+		if end < 0 {
+			end = len(text)
+		} else {
+			for end < len(text) && text[end] == '=' {
+				end++
+			}
+		}
+		chunk, err := base64.StdEncoding.DecodeString(text[:end])
+		if err != nil {
+			return data
+		}
+		decoded = append(decoded, chunk...)
+		text = text[end:]
+	}
+	return decoded
+}
+
+// 解析gRPC-Web的帧（1字节标志 + 4字节长度 + 内容；标志最高位表示trailer帧）
+func parseGRPCWebFrames(data []byte) grpcWebFrames {
+	var frames grpcWebFrames
+	for len(data) >= 5 {
+		flags := data[0]
+		length := binary.BigEndian.Uint32(data[1:5])
+		if uint64(length) > uint64(len(data)-5) {
+			break
+		}
+		payload := data[5 : 5+length]
+		data = data[5+length:]
+
+		if flags&0x01 != 0 {
+			frames.compressed = true
+		}
+		if flags&0x80 != 0 {
+			frames.trailers = parseGRPCTrailers(payload)
+			continue
+		}
+		frames.messages = append(frames.messages, payload)
+	}
+	return frames
+}
+
+// 解析trailer帧中的HTTP头格式内容
+func parseGRPCTrailers(payload []byte) map[string]string {
+	trailers := make(map[string]string)
+	for _, line := range strings.Split(string(payload), "\n") {
+		name, value, found := strings.Cut(strings.TrimRight(line, "\r"), ":")
+		if found {
+			trailers[strings.ToLower(textproto.TrimString(name))] = textproto.TrimString(value)
+		}
+	}
+	return trailers
+}
+
+// 非空的消息列表
+func nonEmpty(data []byte) [][]byte {
+	if len(data) == 0 {
+		return nil
+	}
+	return [][]byte{data}
+}
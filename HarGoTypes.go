@@ -0,0 +1,280 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// 从多个JSON样本推断出的结构
+type jsonShape struct {
+	samples int            // 样本数
+	kinds   map[string]int // 值类型（null、bool、int、float、string、object、array）及出现次数
+	objects int            // 对象样本数，用于判断字段是否可选
+	fields  map[string]*jsonShape
+	order   []string       // 字段首次出现的顺序
+	counts  map[string]int // 字段在对象样本中出现的次数
+	element *jsonShape     // 数组元素
+}
+
+func newJSONShape() *jsonShape {
+	return &jsonShape{kinds: make(map[string]int)}
+}
+
+// 解析JSON文本并加入样本（数字保留整数/小数的区别）
+func (s *jsonShape) addJSON(data []byte) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return err
+	}
+	s.add(value)
+	return nil
+}
+
+// 加入一个已解析的JSON值
+func (s *jsonShape) add(value interface{}) {
+	s.samples++
+	switch v := value.(type) {
+	case nil:
+		s.kinds["null"]++
+	case bool:
+		s.kinds["bool"]++
+	case json.Number:
+		if strings.ContainsAny(v.String(), ".eE") {
+			s.kinds["float"]++
+		} else {
+			s.kinds["int"]++
+		}
+	case float64:
+		if v == float64(int64(v)) {
+			s.kinds["int"]++
+		} else {
+			s.kinds["float"]++
+		}
+	case string:
+		s.kinds["string"]++
+	case map[string]interface{}:
+		s.kinds["object"]++
+		s.objects++
+		if s.fields == nil {
+			s.fields = make(map[string]*jsonShape)
+			s.counts = make(map[string]int)
+		}
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			field, exists := s.fields[key]
+			if !exists {
+				field = newJSONShape()
+				s.fields[key] = field
+				s.order = append(s.order, key)
+			}
+			s.counts[key]++
+			field.add(v[key])
+		}
+	case []interface{}:
+		s.kinds["array"]++
+		if s.element == nil {
+			s.element = newJSONShape()
+		}
+		for _, item := range v {
+			s.element.add(item)
+		}
+	}
+}
+
+// 除null之外的值类型
+func (s *jsonShape) kind() string {
+	kind := ""
+	for k := range s.kinds {
+		switch {
+		case k == "null":
+		case kind == "":
+			kind = k
+		case kind+k == "intfloat" || kind+k == "floatint":
+			kind = "float"
+		default:
+			return "mixed"
+		}
+	}
+	return kind
+}
+
+// 是否出现过null
+func (s *jsonShape) nullable() bool {
+	return s.kinds["null"] > 0
+}
+
+// Go代码生成器（生成的结构体按依赖顺序排列，名称不重复）
+type goTypeGenerator struct {
+	structs []string
+	names   map[string]bool
+}
+
+func newGoTypeGenerator() *goTypeGenerator {
+	return &goTypeGenerator{names: make(map[string]bool)}
+}
+
+// 生成名为 name 的结构体，返回实际使用的类型表达式
+func (g *goTypeGenerator) generate(name string, shape *jsonShape) string {
+	return g.goType(goTypeName(name), shape)
+}
+
+// 所有生成的结构体定义（外层结构体在前）
+func (g *goTypeGenerator) code() []string {
+	return g.structs
+}
+
+func (g *goTypeGenerator) goType(name string, shape *jsonShape) string {
+	switch shape.kind() {
+	case "bool":
+		return g.nullableType("bool", shape)
+	case "int":
+		return g.nullableType("int64", shape)
+	case "float":
+		return g.nullableType("float64", shape)
+	case "string":
+		return g.nullableType("string", shape)
+	case "array":
+		if shape.element == nil || shape.element.samples == 0 {
+			return "[]interface{}"
+		}
+		return "[]" + g.goType(singularName(name), shape.element)
+	case "object":
+		if len(shape.fields) == 0 {
+			return "map[string]interface{}"
+		}
+		typeName := g.structName(name)
+		g.addStruct(typeName, shape)
+		return g.nullableType(typeName, shape)
+	}
+	return "interface{}"
+}
+
+// 可能为null的值使用指针
+func (g *goTypeGenerator) nullableType(goType string, shape *jsonShape) string {
+	if shape.nullable() {
+		return "*" + goType
+	}
+	return goType
+}
+
+// 不与已生成的结构体重名的类型名
+func (g *goTypeGenerator) structName(name string) string {
+	typeName := name
+	for i := 2; g.names[typeName]; i++ {
+		typeName = fmt.Sprintf("%s%d", name, i)
+	}
+	g.names[typeName] = true
+	return typeName
+}
+
+func (g *goTypeGenerator) addStruct(typeName string, shape *jsonShape) {
+	type goField struct {
+		name, goType, tag string
+	}
+	// 先占位，嵌套的结构体排在外层结构体之后
+	index := len(g.structs)
+	g.structs = append(g.structs, "")
+
+	var fields []goField
+	used := make(map[string]bool)
+	nameWidth, typeWidth := 0, 0
+	for _, key := range shape.order {
+		fieldName := goTypeName(key)
+		for i := 2; used[fieldName]; i++ {
+			fieldName = fmt.Sprintf("%s%d", goTypeName(key), i)
+		}
+		used[fieldName] = true
+
+		tag := key
+		if shape.counts[key] < shape.objects {
+			tag += ",omitempty"
+		}
+		field := goField{fieldName, g.goType(typeName+fieldName, shape.fields[key]), "`json:\"" + tag + "\"`"}
+		fields = append(fields, field)
+		nameWidth = maxInt(nameWidth, len(field.name))
+		typeWidth = maxInt(typeWidth, len(field.goType))
+	}
+
+	var code strings.Builder
+	code.WriteString("type " + typeName + " struct {\n")
+	for _, field := range fields {
+		fmt.Fprintf(&code, "\t%-*s %-*s %s\n", nameWidth, field.name, typeWidth, field.goType, field.tag)
+	}
+	code.WriteString("}")
+	g.structs[index] = code.String()
+}
+
+// 常见缩写（按Go命名习惯全部大写）
+var goInitialisms = map[string]bool{
+	"ID": true, "URL": true, "URI": true, "API": true, "HTTP": true, "HTTPS": true, "JSON": true, "XML": true,
+	"UUID": true, "IP": true, "SQL": true, "HTML": true, "CSS": true, "UI": true, "TTL": true, "SSL": true, "TLS": true,
+}
+
+// 将JSON键或名称转换为导出的Go标识符（user_id → UserID，createdAt → CreatedAt）
+func goTypeName(name string) string {
+	var words []string
+	var word []rune
+	flush := func() {
+		if len(word) > 0 {
+			words = append(words, string(word))
+			word = nil
+		}
+	}
+	runes := []rune(name)
+	for i, r := range runes {
+		switch {
+		case !unicode.IsLetter(r) && !unicode.IsDigit(r):
+			flush()
+		case unicode.IsUpper(r) && len(word) > 0 &&
+			(unicode.IsLower(word[len(word)-1]) || i+1 < len(runes) && unicode.IsLower(runes[i+1])):
+			flush()
+			word = append(word, r)
+		default:
+			word = append(word, r)
+		}
+	}
+	flush()
+
+	var builder strings.Builder
+	for _, w := range words {
+		if upper := strings.ToUpper(w); goInitialisms[upper] {
+			builder.WriteString(upper)
+			continue
+		}
+		wordRunes := []rune(w)
+		builder.WriteRune(unicode.ToUpper(wordRunes[0]))
+		builder.WriteString(string(wordRunes[1:]))
+	}
+
+	identifier := builder.String()
+	if identifier == "" {
+		return "Field"
+	}
+	if first := []rune(identifier)[0]; !unicode.IsLetter(first) {
+		identifier = "F" + identifier
+	}
+	return identifier
+}
+
+// 数组元素的类型名（items → Item，addresses → Address）
+func singularName(name string) string {
+	switch {
+	case strings.HasSuffix(name, "ies") && len(name) > 3:
+		return name[:len(name)-3] + "y"
+	case strings.HasSuffix(name, "sses"), strings.HasSuffix(name, "shes"), strings.HasSuffix(name, "ches"), strings.HasSuffix(name, "xes"):
+		return name[:len(name)-2]
+	case strings.HasSuffix(name, "s") && !strings.HasSuffix(name, "ss") && !strings.HasSuffix(name, "us") && len(name) > 1:
+		return name[:len(name)-1]
+	}
+	return name + "Item"
+}
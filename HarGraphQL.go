@@ -0,0 +1,796 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// 每个操作最多记录的不同错误信息数
+const maxGraphQLErrorMessages = 10
+
+// GraphQL分析结果
+type GraphQLAnalysis struct {
+	Requests   int                `json:"requests"`          // GraphQL HTTP请求数
+	Batched    int                `json:"batched"`           // 批量请求数（请求体为数组）
+	Operations []GraphQLOperation `json:"operations"`        // 按操作名和类型分组的统计
+	Schema     string             `json:"schema,omitempty"`  // 根据观察到的查询和响应推断的部分SDL
+	GoTypes    []string           `json:"goTypes,omitempty"` // 变量和响应数据的Go类型
+}
+
+// 单个GraphQL操作的统计
+type GraphQLOperation struct {
+	Name             string            `json:"name"`                       // 操作名（匿名操作为空）
+	Type             string            `json:"type"`                       // query、mutation、subscription（只有持久化查询哈希时为空）
+	Endpoint         string            `json:"endpoint"`                   // 主机+路径
+	RootFields       []string          `json:"rootFields,omitempty"`       // 顶层字段
+	PersistedHash    string            `json:"persistedHash,omitempty"`    // 持久化查询的 sha256Hash
+	Calls            int               `json:"calls"`                      // 调用次数（批量请求中的每个操作各算一次）
+	BatchedCalls     int               `json:"batchedCalls,omitempty"`     // 其中通过批量请求发送的次数
+	Errors           int               `json:"errors"`                     // 响应包含errors或HTTP请求失败的次数
+	ErrorMessages    map[string]int    `json:"errorMessages,omitempty"`    // 错误信息及出现次数
+	Variables        map[string]string `json:"variables,omitempty"`        // 变量名 -> 类型（来自变量定义或根据值推断）
+	VariablesExample string            `json:"variablesExample,omitempty"` // 变量示例（已脱敏）
+	Latency          LatencyStats      `json:"latency"`                    // 耗时统计（毫秒，批量请求中的操作使用整个请求的耗时）
+
+	durations []float64
+	variables *jsonShape
+	data      *jsonShape
+}
+
+// 从HTTP请求中解析出的一个GraphQL操作
+type graphQLRequest struct {
+	query         string
+	operationName string
+	hash          string      // 持久化查询的 sha256Hash
+	variables     interface{} // 变量（数字保留为 json.Number）
+	batchIndex    int         // 在批量请求中的位置（非批量请求为-1）
+}
+
+// 一次GraphQL操作调用
+type graphQLCall struct {
+	entry    *HAREntry
+	endpoint string
+	request  graphQLRequest
+}
+
+// 在分析过程中收集GraphQL调用
+type graphQLCollector struct {
+	ua       *UniversalHARAnalyzer
+	calls    []graphQLCall
+	requests int
+	batched  int
+}
+
+// 解析请求中的GraphQL操作（支持GET参数、JSON请求体、批量数组、application/graphql和持久化查询），不是GraphQL请求时返回nil
+func (ua *UniversalHARAnalyzer) graphQLRequests(entry *HAREntry) []graphQLRequest {
+	params := ua.queryParams(entry)
+	if strings.EqualFold(entry.Request.Method, "GET") {
+		if request, ok := graphQLRequestFromParams(params); ok {
+			return []graphQLRequest{request}
+		}
+		return nil
+	}
+
+	body := strings.TrimSpace(entry.Request.PostData.Text)
+	if body == "" {
+		return nil
+	}
+	mimeType := strings.ToLower(entry.Request.PostData.MimeType)
+	if strings.Contains(mimeType, "application/graphql") && !strings.Contains(mimeType, "json") {
+		request, _ := graphQLRequestFromParams(params)
+		request.query = body
+		if looksLikeGraphQL(body) {
+			return []graphQLRequest{request}
+		}
+		return nil
+	}
+
+	decoded, err := decodeJSONWithNumbers(body)
+	if err != nil {
+		return nil
+	}
+	switch v := decoded.(type) {
+	case map[string]interface{}:
+		if request, ok := graphQLRequestFromObject(v); ok {
+			return []graphQLRequest{request}
+		}
+	case []interface{}:
+		var requests []graphQLRequest
+		for i, item := range v {
+			object, isObject := item.(map[string]interface{})
+			if !isObject {
+				return nil
+			}
+			request, ok := graphQLRequestFromObject(object)
+			if !ok {
+				return nil
+			}
+			request.batchIndex = i
+			requests = append(requests, request)
+		}
+		return requests
+	}
+	return nil
+}
+
+// 从JSON对象中读取GraphQL请求（query、operationName、variables、extensions.persistedQuery）
+func graphQLRequestFromObject(object map[string]interface{}) (graphQLRequest, bool) {
+	request := graphQLRequest{batchIndex: -1, variables: object["variables"]}
+	request.query, _ = object["query"].(string)
+	request.operationName, _ = object["operationName"].(string)
+	if extensions, ok := object["extensions"].(map[string]interface{}); ok {
+		request.hash = persistedQueryHash(extensions)
+	}
+	return request, looksLikeGraphQL(request.query) || request.hash != ""
+}
+
+// 从URL查询参数中读取GraphQL请求（variables 和 extensions 为JSON字符串）
+func graphQLRequestFromParams(params []HARNameValue) (graphQLRequest, bool) {
+	request := graphQLRequest{batchIndex: -1}
+	for _, param := range params {
+		switch param.Name {
+		case "query":
+			request.query = param.Value
+		case "operationName":
+			request.operationName = param.Value
+		case "variables":
+			request.variables, _ = decodeJSONWithNumbers(param.Value)
+		case "extensions":
+			if extensions, err := decodeJSONWithNumbers(param.Value); err == nil {
+				if object, ok := extensions.(map[string]interface{}); ok {
+					request.hash = persistedQueryHash(object)
+				}
+			}
+		}
+	}
+	return request, looksLikeGraphQL(request.query) || request.hash != ""
+}
+
+// 持久化查询（Automatic Persisted Queries）的哈希
+func persistedQueryHash(extensions map[string]interface{}) string {
+	if persisted, ok := extensions["persistedQuery"].(map[string]interface{}); ok {
+		hash, _ := persisted["sha256Hash"].(string)
+		return hash
+	}
+	return ""
+}
+
+// 解析JSON（数字保留为 json.Number，以便区分整数和小数）
+func decodeJSONWithNumbers(text string) (interface{}, error) {
+	decoder := json.NewDecoder(strings.NewReader(text))
+	decoder.UseNumber()
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return nil, err
+	}
+	return value, nil
+}
+
+func newGraphQLCollector(ua *UniversalHARAnalyzer) *graphQLCollector {
+	return &graphQLCollector{ua: ua}
+}
+
+// 记录一个GraphQL HTTP请求中的所有操作
+func (c *graphQLCollector) add(entry *HAREntry, endpoint string, requests []graphQLRequest) {
+	if len(requests) == 0 {
+		return
+	}
+	c.requests++
+	if requests[0].batchIndex >= 0 {
+		c.batched++
+	}
+	for _, request := range requests {
+		c.calls = append(c.calls, graphQLCall{entry: entry, endpoint: endpoint, request: request})
+	}
+}
+
+// 汇总所有调用（没有GraphQL请求时返回nil）
+func (c *graphQLCollector) result() *GraphQLAnalysis {
+	if len(c.calls) == 0 {
+		return nil
+	}
+	ua := c.ua
+
+	// 持久化查询重试时会同时发送哈希和完整查询，用于补全只有哈希的调用
+	hashQueries := make(map[string]string)
+	for _, call := range c.calls {
+		if call.request.hash != "" && call.request.query != "" {
+			hashQueries[call.request.hash] = call.request.query
+		}
+	}
+
+	documents := make(map[string]*gqlDocument)
+	responses := make(map[*HAREntry]interface{})
+	schema := newSDLSchema()
+	operations := make(map[string]*GraphQLOperation)
+	var order []string
+
+	for _, call := range c.calls {
+		request := call.request
+		query := request.query
+		if query == "" {
+			query = hashQueries[request.hash]
+		}
+
+		// 解析查询文档并找到本次执行的操作
+		doc, parsed := documents[query]
+		if !parsed && query != "" {
+			doc, _ = parseGraphQL(query)
+			documents[query] = doc
+		}
+		var definition *gqlOperationDef
+		if doc != nil {
+			for _, candidate := range doc.operations {
+				if request.operationName == "" || candidate.name == request.operationName {
+					definition = candidate
+					break
+				}
+			}
+		}
+
+		name := request.operationName
+		opType := ""
+		var rootFields []string
+		if definition != nil {
+			if name == "" {
+				name = definition.name
+			}
+			opType = definition.kind
+			rootFields = definition.rootFields(doc)
+		} else if query != "" {
+			opType = graphQLOperationKeyword(query)
+		}
+
+		key := call.endpoint + " " + opType + " " + name
+		if name == "" {
+			key += " " + strings.Join(rootFields, ",")
+			if len(rootFields) == 0 {
+				key += " " + request.hash
+			}
+		}
+		op, exists := operations[key]
+		if !exists {
+			op = &GraphQLOperation{
+				Name:       name,
+				Type:       opType,
+				Endpoint:   call.endpoint,
+				RootFields: rootFields,
+				Variables:  make(map[string]string),
+				variables:  newJSONShape(),
+				data:       newJSONShape(),
+			}
+			operations[key] = op
+			order = append(order, key)
+		}
+		if op.PersistedHash == "" {
+			op.PersistedHash = request.hash
+		}
+		op.Calls++
+		if request.batchIndex >= 0 {
+			op.BatchedCalls++
+		}
+		op.durations = append(op.durations, roundMillis(call.entry.Time))
+
+		// 变量
+		variables, _ := request.variables.(map[string]interface{})
+		if definition != nil {
+			for _, variable := range definition.variables {
+				op.Variables[variable.name] = variable.typ
+			}
+		}
+		for variable, value := range variables {
+			if _, declared := op.Variables[variable]; !declared {
+				op.Variables[variable] = graphQLValueType(variable, value)
+			}
+		}
+		if len(variables) > 0 {
+			op.variables.add(variables)
+			if op.VariablesExample == "" {
+				op.VariablesExample = ua.redactGraphQLVariables(variables)
+			}
+		}
+
+		// 响应中的错误和数据
+		response, decoded := responses[call.entry]
+		if !decoded {
+			response, _ = decodeJSONWithNumbers(responseBodyText(call.entry))
+			responses[call.entry] = response
+		}
+		if items, ok := response.([]interface{}); ok && request.batchIndex >= 0 {
+			response = nil
+			if request.batchIndex < len(items) {
+				response = items[request.batchIndex]
+			}
+		}
+		object, _ := response.(map[string]interface{})
+
+		errors, _ := object["errors"].([]interface{})
+		failed := call.entry.Response.Status == 0 || call.entry.Response.Status >= 400
+		if len(errors) > 0 || failed {
+			op.Errors++
+			if op.ErrorMessages == nil {
+				op.ErrorMessages = make(map[string]int)
+			}
+			messages := graphQLErrorMessages(errors)
+			if len(messages) == 0 {
+				messages = []string{strings.TrimSpace(fmt.Sprintf("HTTP %d %s", call.entry.Response.Status, call.entry.Response.StatusText))}
+			}
+			for _, message := range messages {
+				message = ua.redactValue(message)
+				if _, seen := op.ErrorMessages[message]; seen || len(op.ErrorMessages) < maxGraphQLErrorMessages {
+					op.ErrorMessages[message]++
+				}
+			}
+		}
+
+		data, _ := object["data"].(map[string]interface{})
+		if data != nil {
+			op.data.add(data)
+		}
+		if definition != nil {
+			schema.addOperation(doc, definition, data, variables)
+		}
+	}
+
+	result := &GraphQLAnalysis{Requests: c.requests, Batched: c.batched}
+	for _, key := range order {
+		op := operations[key]
+		op.Latency = computeLatencyStats(op.durations)
+		if len(op.Variables) == 0 {
+			op.Variables = nil
+		}
+		result.Operations = append(result.Operations, *op)
+	}
+	sort.SliceStable(result.Operations, func(i, j int) bool {
+		return result.Operations[i].Calls > result.Operations[j].Calls
+	})
+
+	// 生成Go类型（每个操作的变量和响应数据）
+	generator := newGoTypeGenerator()
+	for _, op := range result.Operations {
+		base := op.Name
+		if base == "" {
+			base = strings.Join(op.RootFields, "_")
+		}
+		if base == "" {
+			base = "Anonymous" + goTypeName(op.Type)
+		}
+		if op.variables.samples > 0 {
+			generator.generate(base+"Variables", op.variables)
+		}
+		if op.data.samples > 0 {
+			generator.generate(base+"Data", op.data)
+		}
+	}
+	result.GoTypes = generator.code()
+	result.Schema = schema.String()
+	return result
+}
+
+// 报告中显示的操作名（匿名操作显示顶层字段，只有哈希时显示哈希前缀）
+func (op GraphQLOperation) DisplayName() string {
+	switch {
+	case op.Name != "":
+		return op.Name
+	case len(op.RootFields) > 0:
+		return "{ " + strings.Join(op.RootFields, ", ") + " }"
+	case len(op.PersistedHash) > 12:
+		return "#" + op.PersistedHash[:12]
+	case op.PersistedHash != "":
+		return "#" + op.PersistedHash
+	}
+	return "-"
+}
+
+// 变量列表（$id: ID!, $first: Int）
+func (op GraphQLOperation) VariableList() string {
+	names := make([]string, 0, len(op.Variables))
+	for name := range op.Variables {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for i, name := range names {
+		names[i] = "$" + name + ": " + op.Variables[name]
+	}
+	return strings.Join(names, ", ")
+}
+
+// 没有解析出操作定义时根据开头的关键字判断操作类型
+func graphQLOperationKeyword(query string) string {
+	query = skipGraphQLIgnored(query)
+	for _, keyword := range []string{"mutation", "subscription"} {
+		if strings.HasPrefix(query, keyword) {
+			return keyword
+		}
+	}
+	return "query"
+}
+
+// errors数组中的错误信息
+func graphQLErrorMessages(errors []interface{}) []string {
+	var messages []string
+	for _, item := range errors {
+		if object, ok := item.(map[string]interface{}); ok {
+			if message, ok := object["message"].(string); ok && message != "" {
+				messages = append(messages, message)
+			}
+		}
+	}
+	return messages
+}
+
+// 变量示例（按变量名和正则规则脱敏后的紧凑JSON）
+func (ua *UniversalHARAnalyzer) redactGraphQLVariables(variables map[string]interface{}) string {
+	redacted := make(map[string]interface{}, len(variables))
+	for name, value := range variables {
+		if text, ok := value.(string); ok {
+			redacted[name] = ua.redactParam(name, text)
+		} else {
+			redacted[name] = value
+		}
+	}
+	data, err := json.Marshal(redacted)
+	if err != nil {
+		return ""
+	}
+	return ua.redactValue(string(data))
+}
+
+// 根据变量值推断GraphQL类型
+func graphQLValueType(name string, value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		if name == "id" || strings.HasSuffix(name, "Id") || strings.HasSuffix(name, "ID") {
+			return "ID"
+		}
+		return "String"
+	case json.Number:
+		if strings.ContainsAny(v.String(), ".eE") {
+			return "Float"
+		}
+		return "Int"
+	case bool:
+		return "Boolean"
+	case []interface{}:
+		for _, item := range v {
+			if item != nil {
+				return "[" + graphQLValueType(singularName(name), item) + "]"
+			}
+		}
+		return "[JSON]"
+	}
+	return "JSON"
+}
+
+// 根据观察到的查询和响应推断的部分GraphQL Schema
+type sdlSchema struct {
+	types   map[string]*sdlType
+	order   []string
+	scalars map[string]bool
+	unions  map[string][]string
+}
+
+type sdlType struct {
+	keyword string // type 或 input
+	fields  map[string]*sdlField
+	order   []string
+}
+
+type sdlField struct {
+	typ       string
+	arguments []gqlVariableDef
+}
+
+// GraphQL内置标量
+var graphQLBuiltinScalars = map[string]bool{"Int": true, "Float": true, "String": true, "Boolean": true, "ID": true}
+
+func newSDLSchema() *sdlSchema {
+	return &sdlSchema{types: make(map[string]*sdlType), scalars: make(map[string]bool), unions: make(map[string][]string)}
+}
+
+// 记录联合类型的成员
+func (s *sdlSchema) addUnion(name string, members []string) {
+	for _, member := range members {
+		if !containsString(s.unions[name], member) {
+			s.unions[name] = append(s.unions[name], member)
+		}
+	}
+}
+
+func (s *sdlSchema) typ(name, keyword string) *sdlType {
+	t, exists := s.types[name]
+	if !exists {
+		t = &sdlType{keyword: keyword, fields: make(map[string]*sdlField)}
+		s.types[name] = t
+		s.order = append(s.order, name)
+	}
+	return t
+}
+
+// 记录一次操作的选择集、参数类型和变量中的输入类型
+func (s *sdlSchema) addOperation(doc *gqlDocument, op *gqlOperationDef, data, variables map[string]interface{}) {
+	variableTypes := make(map[string]string)
+	for _, variable := range op.variables {
+		variableTypes[variable.name] = variable.typ
+
+		named := strings.Trim(variable.typ, "[]!")
+		if graphQLBuiltinScalars[named] {
+			continue
+		}
+		if object, ok := firstJSONObject(variables[variable.name]); ok {
+			input := s.typ(named, "input")
+			fields := make([]string, 0, len(object))
+			for field := range object {
+				fields = append(fields, field)
+			}
+			sort.Strings(fields)
+			for _, field := range fields {
+				input.field(field).merge(graphQLValueType(field, object[field]))
+			}
+		} else if existing, defined := s.types[named]; !defined || existing.keyword != "input" {
+			s.scalars[named] = true
+		}
+	}
+
+	rootName := "Query"
+	switch op.kind {
+	case "mutation":
+		rootName = "Mutation"
+	case "subscription":
+		rootName = "Subscription"
+	}
+	s.walk(doc, rootName, op.selections, data, variableTypes, 0)
+}
+
+// 按选择集遍历响应数据，记录每个类型的字段
+func (s *sdlSchema) walk(doc *gqlDocument, typeName string, selections []*gqlSelection, data map[string]interface{}, variableTypes map[string]string, depth int) {
+	if depth > 32 {
+		return
+	}
+	t := s.typ(typeName, "type")
+	for _, selection := range selections {
+		switch {
+		case selection.spread != "":
+			if fragment := doc.fragments[selection.spread]; fragment != nil && fragmentApplies(fragment.typeCondition, fragment.selections, data) {
+				s.walk(doc, typeName, fragment.selections, data, variableTypes, depth+1)
+			}
+		case selection.inline:
+			if fragmentApplies(selection.typeCondition, selection.children, data) {
+				s.walk(doc, typeName, selection.children, data, variableTypes, depth+1)
+			}
+		case selection.name == "__typename":
+		default:
+			field := t.field(selection.name)
+			field.merge(s.valueType(doc, selection, data[selection.responseKey()], false, variableTypes, depth))
+			for _, argument := range selection.arguments {
+				argType := variableTypes[argument.variable]
+				if argument.variable == "" {
+					argType = graphQLLiteralType(argument.kind)
+				}
+				field.addArgument(argument.name, argType)
+			}
+		}
+	}
+}
+
+// 字段值对应的GraphQL类型（对象会继续遍历子选择集）
+func (s *sdlSchema) valueType(doc *gqlDocument, selection *gqlSelection, value interface{}, inList bool, variableTypes map[string]string, depth int) string {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		if len(selection.children) == 0 {
+			return "JSON"
+		}
+		name := sdlObjectTypeName(selection, v, inList)
+		s.walk(doc, name, selection.children, v, variableTypes, depth+1)
+		return name
+	case []interface{}:
+		// 元素的 __typename 不同时声明为联合类型
+		elementType := ""
+		var members []string
+		for _, item := range v {
+			itemType := s.valueType(doc, selection, item, true, variableTypes, depth)
+			if _, isObject := item.(map[string]interface{}); isObject && itemType != "JSON" && !containsString(members, itemType) {
+				members = append(members, itemType)
+			}
+			elementType = mergeSDLType(elementType, itemType)
+		}
+		if len(members) > 1 {
+			elementType = goTypeName(selection.name) + "Result"
+			s.addUnion(elementType, members)
+		}
+		if elementType == "" {
+			return "[JSON]"
+		}
+		return "[" + elementType + "]"
+	case nil:
+		// null或缺失的值无法确定类型
+		return ""
+	}
+	return graphQLValueType(selection.name, value)
+}
+
+// 对象类型名：优先使用 __typename，其次使用片段的类型条件，否则根据字段名生成
+func sdlObjectTypeName(selection *gqlSelection, object map[string]interface{}, inList bool) string {
+	if typename, ok := object["__typename"].(string); ok && typename != "" {
+		return typename
+	}
+	condition := ""
+	for _, child := range selection.children {
+		if !child.inline && child.spread == "" {
+			continue
+		}
+		if child.typeCondition == "" || condition != "" && child.typeCondition != condition {
+			condition = ""
+			break
+		}
+		condition = child.typeCondition
+	}
+	if condition != "" {
+		return condition
+	}
+	if inList {
+		return goTypeName(singularName(selection.name))
+	}
+	return goTypeName(selection.name)
+}
+
+// 有 __typename 且不等于类型条件时，只有响应中包含片段的字段才认为片段适用（接口片段）
+func fragmentApplies(typeCondition string, selections []*gqlSelection, data map[string]interface{}) bool {
+	typename, ok := data["__typename"].(string)
+	if !ok || typeCondition == "" || typename == typeCondition {
+		return true
+	}
+	for _, selection := range selections {
+		if selection.spread == "" && !selection.inline && selection.name != "__typename" {
+			if _, present := data[selection.responseKey()]; present {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// 字面量参数的类型
+func graphQLLiteralType(kind string) string {
+	switch kind {
+	case "Int", "Float", "String", "Boolean":
+		return kind
+	}
+	return "JSON"
+}
+
+// 变量值中的第一个对象（列表类型的变量取第一个元素）
+func firstJSONObject(value interface{}) (map[string]interface{}, bool) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		return v, true
+	case []interface{}:
+		for _, item := range v {
+			if object, ok := item.(map[string]interface{}); ok {
+				return object, true
+			}
+		}
+	}
+	return nil, false
+}
+
+func (t *sdlType) field(name string) *sdlField {
+	field, exists := t.fields[name]
+	if !exists {
+		field = &sdlField{}
+		t.fields[name] = field
+		t.order = append(t.order, name)
+	}
+	return field
+}
+
+func (f *sdlField) merge(typ string) {
+	f.typ = mergeSDLType(f.typ, typ)
+}
+
+func (f *sdlField) addArgument(name, typ string) {
+	for i, argument := range f.arguments {
+		if argument.name == name {
+			f.arguments[i].typ = mergeSDLType(argument.typ, typ)
+			return
+		}
+	}
+	f.arguments = append(f.arguments, gqlVariableDef{name: name, typ: typ})
+}
+
+// 合并两次观察到的类型（未知类型被具体类型取代，Int和Float合并为Float）
+func mergeSDLType(current, observed string) string {
+	switch {
+	case observed == "" || observed == current:
+		return current
+	case current == "" || current == "JSON" || current == "[JSON]" && strings.HasPrefix(observed, "["):
+		return observed
+	case current == "Int" && observed == "Float":
+		return observed
+	}
+	return current
+}
+
+// 输出SDL（根类型在前，输入类型在后，未知类型声明为标量）
+func (s *sdlSchema) String() string {
+	var names []string
+	for _, root := range []string{"Query", "Mutation", "Subscription"} {
+		if _, exists := s.types[root]; exists {
+			names = append(names, root)
+		}
+	}
+	for _, keyword := range []string{"type", "input"} {
+		for _, name := range s.order {
+			if t := s.types[name]; t.keyword == keyword && name != "Query" && name != "Mutation" && name != "Subscription" {
+				names = append(names, name)
+			}
+		}
+	}
+
+	var body bytes.Buffer
+	scalars := make(map[string]bool)
+	for name := range s.scalars {
+		if _, defined := s.types[name]; !defined {
+			scalars[name] = true
+		}
+	}
+	for _, name := range names {
+		t := s.types[name]
+		if len(t.fields) == 0 {
+			continue
+		}
+		body.WriteString(t.keyword + " " + name + " {\n")
+		for _, fieldName := range t.order {
+			field := t.fields[fieldName]
+			typ := field.typ
+			if typ == "" || strings.Contains(typ, "JSON") {
+				scalars["JSON"] = true
+			}
+			if typ == "" {
+				typ = "JSON"
+			}
+			body.WriteString("  " + fieldName)
+			if len(field.arguments) > 0 {
+				var arguments []string
+				for _, argument := range field.arguments {
+					if strings.Contains(argument.typ, "JSON") {
+						scalars["JSON"] = true
+					}
+					arguments = append(arguments, argument.name+": "+argument.typ)
+				}
+				body.WriteString("(" + strings.Join(arguments, ", ") + ")")
+			}
+			body.WriteString(": " + typ + "\n")
+		}
+		body.WriteString("}\n\n")
+	}
+	unionNames := make([]string, 0, len(s.unions))
+	for name := range s.unions {
+		unionNames = append(unionNames, name)
+	}
+	sort.Strings(unionNames)
+	for _, name := range unionNames {
+		body.WriteString("union " + name + " = " + strings.Join(s.unions[name], " | ") + "\n\n")
+	}
+	if body.Len() == 0 {
+		return ""
+	}
+
+	var sdl strings.Builder
+	scalarNames := make([]string, 0, len(scalars))
+	for name := range scalars {
+		scalarNames = append(scalarNames, name)
+	}
+	sort.Strings(scalarNames)
+	for _, name := range scalarNames {
+		sdl.WriteString("scalar " + name + "\n")
+	}
+	if len(scalarNames) > 0 {
+		sdl.WriteString("\n")
+	}
+	sdl.WriteString(strings.TrimRight(body.String(), "\n"))
+	return sdl.String()
+}
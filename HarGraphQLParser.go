@@ -0,0 +1,513 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// GraphQL词法单元
+type gqlToken struct {
+	kind string // name、punct、string、number、eof
+	text string
+}
+
+// 解析后的GraphQL文档（只包含可执行定义）
+type gqlDocument struct {
+	operations []*gqlOperationDef
+	fragments  map[string]*gqlFragmentDef
+}
+
+// 操作定义
+type gqlOperationDef struct {
+	kind       string // query、mutation、subscription
+	name       string
+	variables  []gqlVariableDef
+	selections []*gqlSelection
+}
+
+// 变量定义（$id: ID!）
+type gqlVariableDef struct {
+	name string
+	typ  string
+}
+
+// 片段定义
+type gqlFragmentDef struct {
+	typeCondition string
+	selections    []*gqlSelection
+}
+
+// 选择集中的一项：字段、片段展开（...Name）或内联片段（... on Type）
+type gqlSelection struct {
+	alias         string
+	name          string
+	arguments     []gqlArgument
+	children      []*gqlSelection
+	spread        string
+	inline        bool
+	typeCondition string
+}
+
+// 字段参数（值为变量时记录变量名，否则记录字面量类型）
+type gqlArgument struct {
+	name     string
+	variable string
+	kind     string // Int、Float、String、Boolean、Enum、List、Object、Null
+}
+
+// 响应中的键（有别名时为别名）
+func (s *gqlSelection) responseKey() string {
+	if s.alias != "" {
+		return s.alias
+	}
+	return s.name
+}
+
+// 操作中的顶层字段名
+func (op *gqlOperationDef) rootFields(doc *gqlDocument) []string {
+	var fields []string
+	var collect func(selections []*gqlSelection, depth int)
+	collect = func(selections []*gqlSelection, depth int) {
+		for _, selection := range selections {
+			switch {
+			case selection.spread != "":
+				if fragment := doc.fragments[selection.spread]; fragment != nil && depth < 8 {
+					collect(fragment.selections, depth+1)
+				}
+			case selection.inline:
+				collect(selection.children, depth)
+			case selection.name != "__typename":
+				fields = append(fields, selection.name)
+			}
+		}
+	}
+	collect(op.selections, 0)
+	return fields
+}
+
+// 判断文本是否像GraphQL文档（跳过空白和注释后以 { 或关键字开头）
+func looksLikeGraphQL(text string) bool {
+	text = skipGraphQLIgnored(text)
+	if strings.HasPrefix(text, "{") {
+		return true
+	}
+	for _, keyword := range []string{"query", "mutation", "subscription", "fragment"} {
+		if strings.HasPrefix(text, keyword) {
+			rest := text[len(keyword):]
+			return rest == "" || !isGraphQLNameChar(rest[0])
+		}
+	}
+	return false
+}
+
+func skipGraphQLIgnored(text string) string {
+	for {
+		text = strings.TrimLeft(text, " \t\r\n,\uFEFF")
+		if !strings.HasPrefix(text, "#") {
+			return text
+		}
+		if newline := strings.IndexAny(text, "\r\n"); newline >= 0 {
+			text = text[newline:]
+		} else {
+			return ""
+		}
+	}
+}
+
+func isGraphQLNameChar(c byte) bool {
+	return c == '_' || 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' || '0' <= c && c <= '9'
+}
+
+// 词法分析
+func tokenizeGraphQL(source string) ([]gqlToken, error) {
+	var tokens []gqlToken
+	for i := 0; i < len(source); {
+		c := source[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\r' || c == '\n' || c == ',':
+			i++
+		case strings.HasPrefix(source[i:], "\uFEFF"):
+			i += len("\uFEFF")
+		case c == '#':
+			for i < len(source) && source[i] != '\n' && source[i] != '\r' {
+				i++
+			}
+		case strings.HasPrefix(source[i:], "..."):
+			tokens = append(tokens, gqlToken{"punct", "..."})
+			i += 3
+		case strings.IndexByte("!$&()[]{}:=@|", c) >= 0:
+			tokens = append(tokens, gqlToken{"punct", string(c)})
+			i++
+		case strings.HasPrefix(source[i:], `"""`):
+			j := i + 3
+			for j < len(source) && !strings.HasPrefix(source[j:], `"""`) {
+				if strings.HasPrefix(source[j:], `\"""`) {
+					j += 3
+				}
+				j++
+			}
+			if j >= len(source) {
+				return nil, fmt.Errorf("unterminated block string")
+			}
+			tokens = append(tokens, gqlToken{"string", source[i+3 : j]})
+			i = j + 3
+		case c == '"':
+			j := i + 1
+			for j < len(source) && source[j] != '"' && source[j] != '\n' {
+				if source[j] == '\\' {
+					j++
+				}
+				j++
+			}
+			if j >= len(source) || source[j] != '"' {
+				return nil, fmt.Errorf("unterminated string")
+			}
+			tokens = append(tokens, gqlToken{"string", source[i+1 : j]})
+			i = j + 1
+		case c == '-' || '0' <= c && c <= '9':
+			j := i + 1
+			for j < len(source) && (strings.IndexByte("0123456789.eE+-", source[j]) >= 0) {
+				j++
+			}
+			tokens = append(tokens, gqlToken{"number", source[i:j]})
+			i = j
+		case isGraphQLNameChar(c):
+			j := i + 1
+			for j < len(source) && isGraphQLNameChar(source[j]) {
+				j++
+			}
+			tokens = append(tokens, gqlToken{"name", source[i:j]})
+			i = j
+		default:
+			return nil, fmt.Errorf("unexpected character %q", c)
+		}
+	}
+	return append(tokens, gqlToken{kind: "eof"}), nil
+}
+
+// GraphQL语法分析器（只解析可执行文档，遇到类型系统定义时报错）
+type gqlParser struct {
+	tokens []gqlToken
+	pos    int
+}
+
+// 解析GraphQL文档
+func parseGraphQL(source string) (*gqlDocument, error) {
+	tokens, err := tokenizeGraphQL(source)
+	if err != nil {
+		return nil, err
+	}
+	p := &gqlParser{tokens: tokens}
+	doc := &gqlDocument{fragments: make(map[string]*gqlFragmentDef)}
+	for p.peek().kind != "eof" {
+		tok := p.peek()
+		switch {
+		case tok.text == "{" && tok.kind == "punct":
+			selections, err := p.selectionSet()
+			if err != nil {
+				return nil, err
+			}
+			doc.operations = append(doc.operations, &gqlOperationDef{kind: "query", selections: selections})
+		case tok.kind == "name" && (tok.text == "query" || tok.text == "mutation" || tok.text == "subscription"):
+			op, err := p.operation()
+			if err != nil {
+				return nil, err
+			}
+			doc.operations = append(doc.operations, op)
+		case tok.kind == "name" && tok.text == "fragment":
+			p.pos++
+			name, err := p.expect("name", "")
+			if err != nil {
+				return nil, err
+			}
+			if _, err := p.expect("name", "on"); err != nil {
+				return nil, err
+			}
+			typeCondition, err := p.expect("name", "")
+			if err != nil {
+				return nil, err
+			}
+			if err := p.directives(); err != nil {
+				return nil, err
+			}
+			selections, err := p.selectionSet()
+			if err != nil {
+				return nil, err
+			}
+			doc.fragments[name] = &gqlFragmentDef{typeCondition: typeCondition, selections: selections}
+		default:
+			return nil, fmt.Errorf("unexpected %q", tok.text)
+		}
+	}
+	return doc, nil
+}
+
+func (p *gqlParser) peek() gqlToken {
+	return p.tokens[p.pos]
+}
+
+// 是否为指定的标点
+func (p *gqlParser) at(punct string) bool {
+	tok := p.peek()
+	return tok.kind == "punct" && tok.text == punct
+}
+
+// 读取指定类型（和文本）的词法单元
+func (p *gqlParser) expect(kind, text string) (string, error) {
+	tok := p.peek()
+	if tok.kind != kind || text != "" && tok.text != text {
+		if tok.kind == "eof" {
+			return "", fmt.Errorf("unexpected end of document")
+		}
+		return "", fmt.Errorf("unexpected %q", tok.text)
+	}
+	p.pos++
+	return tok.text, nil
+}
+
+func (p *gqlParser) operation() (*gqlOperationDef, error) {
+	op := &gqlOperationDef{kind: p.peek().text}
+	p.pos++
+	if p.peek().kind == "name" {
+		op.name = p.peek().text
+		p.pos++
+	}
+
+	if p.at("(") {
+		p.pos++
+		for !p.at(")") {
+			if _, err := p.expect("punct", "$"); err != nil {
+				return nil, err
+			}
+			name, err := p.expect("name", "")
+			if err != nil {
+				return nil, err
+			}
+			if _, err := p.expect("punct", ":"); err != nil {
+				return nil, err
+			}
+			typ, err := p.typeRef()
+			if err != nil {
+				return nil, err
+			}
+			if p.at("=") {
+				p.pos++
+				if _, err := p.value(); err != nil {
+					return nil, err
+				}
+			}
+			if err := p.directives(); err != nil {
+				return nil, err
+			}
+			op.variables = append(op.variables, gqlVariableDef{name: name, typ: typ})
+		}
+		p.pos++
+	}
+
+	if err := p.directives(); err != nil {
+		return nil, err
+	}
+	selections, err := p.selectionSet()
+	if err != nil {
+		return nil, err
+	}
+	op.selections = selections
+	return op, nil
+}
+
+// 类型引用（ID!、[String]、[Int!]!）
+func (p *gqlParser) typeRef() (string, error) {
+	var typ string
+	if p.at("[") {
+		p.pos++
+		inner, err := p.typeRef()
+		if err != nil {
+			return "", err
+		}
+		if _, err := p.expect("punct", "]"); err != nil {
+			return "", err
+		}
+		typ = "[" + inner + "]"
+	} else {
+		name, err := p.expect("name", "")
+		if err != nil {
+			return "", err
+		}
+		typ = name
+	}
+	if p.at("!") {
+		p.pos++
+		typ += "!"
+	}
+	return typ, nil
+}
+
+func (p *gqlParser) selectionSet() ([]*gqlSelection, error) {
+	if _, err := p.expect("punct", "{"); err != nil {
+		return nil, err
+	}
+	var selections []*gqlSelection
+	for !p.at("}") {
+		selection, err := p.selection()
+		if err != nil {
+			return nil, err
+		}
+		selections = append(selections, selection)
+	}
+	p.pos++
+	return selections, nil
+}
+
+func (p *gqlParser) selection() (*gqlSelection, error) {
+	if p.at("...") {
+		p.pos++
+		selection := &gqlSelection{}
+		if tok := p.peek(); tok.kind == "name" && tok.text != "on" {
+			selection.spread = tok.text
+			p.pos++
+			return selection, p.directives()
+		}
+		selection.inline = true
+		if tok := p.peek(); tok.kind == "name" && tok.text == "on" {
+			p.pos++
+			typeCondition, err := p.expect("name", "")
+			if err != nil {
+				return nil, err
+			}
+			selection.typeCondition = typeCondition
+		}
+		if err := p.directives(); err != nil {
+			return nil, err
+		}
+		children, err := p.selectionSet()
+		if err != nil {
+			return nil, err
+		}
+		selection.children = children
+		return selection, nil
+	}
+
+	name, err := p.expect("name", "")
+	if err != nil {
+		return nil, err
+	}
+	selection := &gqlSelection{name: name}
+	if p.at(":") {
+		p.pos++
+		if selection.name, err = p.expect("name", ""); err != nil {
+			return nil, err
+		}
+		selection.alias = name
+	}
+	if selection.arguments, err = p.arguments(); err != nil {
+		return nil, err
+	}
+	if err := p.directives(); err != nil {
+		return nil, err
+	}
+	if p.at("{") {
+		if selection.children, err = p.selectionSet(); err != nil {
+			return nil, err
+		}
+	}
+	return selection, nil
+}
+
+func (p *gqlParser) arguments() ([]gqlArgument, error) {
+	if !p.at("(") {
+		return nil, nil
+	}
+	p.pos++
+	var arguments []gqlArgument
+	for !p.at(")") {
+		name, err := p.expect("name", "")
+		if err != nil {
+			return nil, err
+		}
+		if _, err := p.expect("punct", ":"); err != nil {
+			return nil, err
+		}
+		argument := gqlArgument{name: name}
+		if p.at("$") {
+			p.pos++
+			if argument.variable, err = p.expect("name", ""); err != nil {
+				return nil, err
+			}
+		} else if argument.kind, err = p.value(); err != nil {
+			return nil, err
+		}
+		arguments = append(arguments, argument)
+	}
+	p.pos++
+	return arguments, nil
+}
+
+func (p *gqlParser) directives() error {
+	for p.at("@") {
+		p.pos++
+		if _, err := p.expect("name", ""); err != nil {
+			return err
+		}
+		if _, err := p.arguments(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// 跳过一个值，返回它的字面量类型
+func (p *gqlParser) value() (string, error) {
+	tok := p.peek()
+	switch {
+	case tok.kind == "number":
+		p.pos++
+		if strings.ContainsAny(tok.text, ".eE") {
+			return "Float", nil
+		}
+		return "Int", nil
+	case tok.kind == "string":
+		p.pos++
+		return "String", nil
+	case tok.kind == "name":
+		p.pos++
+		switch tok.text {
+		case "true", "false":
+			return "Boolean", nil
+		case "null":
+			return "Null", nil
+		}
+		return "Enum", nil
+	case p.at("$"):
+		p.pos++
+		_, err := p.expect("name", "")
+		return "Variable", err
+	case p.at("["):
+		p.pos++
+		for !p.at("]") {
+			if _, err := p.value(); err != nil {
+				return "", err
+			}
+		}
+		p.pos++
+		return "List", nil
+	case p.at("{"):
+		p.pos++
+		for !p.at("}") {
+			if _, err := p.expect("name", ""); err != nil {
+				return "", err
+			}
+			if _, err := p.expect("punct", ":"); err != nil {
+				return "", err
+			}
+			if _, err := p.value(); err != nil {
+				return "", err
+			}
+		}
+		p.pos++
+		return "Object", nil
+	}
+	if tok.kind == "eof" {
+		return "", fmt.Errorf("unexpected end of document")
+	}
+	return "", fmt.Errorf("unexpected %q", tok.text)
+}
@@ -0,0 +1,37 @@
+package main
+
+// 本文件同时嵌入生成的Go压测程序，只能使用标准库且不依赖本包的其他代码
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// 从JSON数据中按路径取值
+func lookupJSONPath(data []byte, path []string) (string, bool) {
+	var value interface{}
+	if err := json.Unmarshal(data, &value); err != nil {
+		return "", false
+	}
+	for _, key := range path {
+		switch v := value.(type) {
+		case map[string]interface{}:
+			value = v[key]
+		case []interface{}:
+			var index int
+			if _, err := fmt.Sscanf(key, "%d", &index); err != nil || index < 0 || index >= len(v) {
+				return "", false
+			}
+			value = v[index]
+		default:
+			return "", false
+		}
+	}
+	if value == nil {
+		return "", false
+	}
+	if s, ok := value.(string); ok {
+		return s, true
+	}
+	return fmt.Sprint(value), true
+}
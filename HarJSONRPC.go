@@ -0,0 +1,366 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// 每个方法最多记录的不同错误数
+const maxJSONRPCErrors = 10
+
+// JSON-RPC分析结果
+type JSONRPCAnalysis struct {
+	Requests      int             `json:"requests"`           // JSON-RPC HTTP请求数
+	Batched       int             `json:"batched"`            // 批量请求数（请求体为数组）
+	Notifications int             `json:"notifications"`      // 不带id的通知调用数
+	Methods       []JSONRPCMethod `json:"methods"`            // 按端点和方法名分组的统计
+	GoTypes       []string        `json:"goTypes,omitempty"`  // 参数和结果的Go类型
+	GoClient      string          `json:"goClient,omitempty"` // 生成的Go客户端方法
+}
+
+// 单个JSON-RPC方法的统计
+type JSONRPCMethod struct {
+	Name          string         `json:"name"`
+	Endpoint      string         `json:"endpoint"` // 主机+路径
+	Calls         int            `json:"calls"`
+	BatchedCalls  int            `json:"batchedCalls,omitempty"`
+	Notifications int            `json:"notifications,omitempty"`
+	Errors        int            `json:"errors"`                  // 响应包含error或HTTP请求失败的次数
+	ErrorCodes    map[string]int `json:"errorCodes,omitempty"`    // 错误码和信息（-32601 Method not found）及出现次数
+	ParamsStyle   string         `json:"paramsStyle,omitempty"`   // named（对象）、positional（数组）
+	ParamsExample string         `json:"paramsExample,omitempty"` // 参数示例（已脱敏）
+	Latency       LatencyStats   `json:"latency"`                 // 耗时统计（毫秒，批量请求中的调用使用整个请求的耗时）
+
+	durations []float64
+	params    *jsonShape
+	result    *jsonShape
+}
+
+// 从HTTP请求中解析出的一个JSON-RPC调用
+type jsonRPCRequest struct {
+	method     string
+	id         string // 请求id（通知为空）
+	hasID      bool
+	params     interface{}
+	batchIndex int // 在批量请求中的位置（非批量请求为-1）
+}
+
+// 一次JSON-RPC调用
+type jsonRPCCall struct {
+	entry    *HAREntry
+	endpoint string
+	request  jsonRPCRequest
+}
+
+// 在分析过程中收集JSON-RPC调用
+type jsonRPCCollector struct {
+	ua       *UniversalHARAnalyzer
+	calls    []jsonRPCCall
+	requests int
+	batched  int
+}
+
+// 解析请求体中的JSON-RPC 2.0调用（单个或批量），不是JSON-RPC请求时返回nil
+func (ua *UniversalHARAnalyzer) jsonRPCRequests(entry *HAREntry) []jsonRPCRequest {
+	body := strings.TrimSpace(entry.Request.PostData.Text)
+	if body == "" || (body[0] != '{' && body[0] != '[') {
+		return nil
+	}
+	decoded, err := decodeJSONWithNumbers(body)
+	if err != nil {
+		return nil
+	}
+	switch v := decoded.(type) {
+	case map[string]interface{}:
+		if request, ok := jsonRPCRequestFromObject(v); ok {
+			return []jsonRPCRequest{request}
+		}
+	case []interface{}:
+		var requests []jsonRPCRequest
+		for i, item := range v {
+			object, isObject := item.(map[string]interface{})
+			if !isObject {
+				return nil
+			}
+			request, ok := jsonRPCRequestFromObject(object)
+			if !ok {
+				return nil
+			}
+			request.batchIndex = i
+			requests = append(requests, request)
+		}
+		return requests
+	}
+	return nil
+}
+
+// 从JSON对象中读取JSON-RPC请求（需要 "jsonrpc": "2.0" 和字符串类型的method）
+func jsonRPCRequestFromObject(object map[string]interface{}) (jsonRPCRequest, bool) {
+	version, _ := object["jsonrpc"].(string)
+	method, _ := object["method"].(string)
+	if version != "2.0" || method == "" {
+		return jsonRPCRequest{}, false
+	}
+	request := jsonRPCRequest{method: method, params: object["params"], batchIndex: -1}
+	if id, exists := object["id"]; exists {
+		request.id, request.hasID = jsonRPCID(id), true
+	}
+	return request, true
+}
+
+// 用于匹配请求和响应的id（数字和字符串id区分开）
+func jsonRPCID(id interface{}) string {
+	switch v := id.(type) {
+	case string:
+		return "s:" + v
+	case json.Number:
+		return "n:" + v.String()
+	case nil:
+		return "null"
+	}
+	return fmt.Sprint(id)
+}
+
+func newJSONRPCCollector(ua *UniversalHARAnalyzer) *jsonRPCCollector {
+	return &jsonRPCCollector{ua: ua}
+}
+
+// 记录一个JSON-RPC HTTP请求中的所有调用
+func (c *jsonRPCCollector) add(entry *HAREntry, endpoint string, requests []jsonRPCRequest) {
+	if len(requests) == 0 {
+		return
+	}
+	c.requests++
+	if requests[0].batchIndex >= 0 {
+		c.batched++
+	}
+	for _, request := range requests {
+		c.calls = append(c.calls, jsonRPCCall{entry: entry, endpoint: endpoint, request: request})
+	}
+}
+
+// 汇总所有调用（没有JSON-RPC请求时返回nil）
+func (c *jsonRPCCollector) result() *JSONRPCAnalysis {
+	if len(c.calls) == 0 {
+		return nil
+	}
+	ua := c.ua
+
+	responses := make(map[*HAREntry]map[string]map[string]interface{})
+	methods := make(map[string]*JSONRPCMethod)
+	var order []string
+	notifications := 0
+
+	for _, call := range c.calls {
+		request := call.request
+		key := call.endpoint + " " + request.method
+		method, exists := methods[key]
+		if !exists {
+			method = &JSONRPCMethod{
+				Name:     request.method,
+				Endpoint: call.endpoint,
+				params:   newJSONShape(),
+				result:   newJSONShape(),
+			}
+			methods[key] = method
+			order = append(order, key)
+		}
+		method.Calls++
+		if request.batchIndex >= 0 {
+			method.BatchedCalls++
+		}
+		method.durations = append(method.durations, roundMillis(call.entry.Time))
+
+		// 参数
+		switch request.params.(type) {
+		case map[string]interface{}:
+			method.ParamsStyle = "named"
+		case []interface{}:
+			method.ParamsStyle = "positional"
+		}
+		if request.params != nil {
+			method.params.add(request.params)
+			if method.ParamsExample == "" {
+				method.ParamsExample = ua.redactJSONRPCParams(request.params)
+			}
+		}
+
+		if !request.hasID {
+			method.Notifications++
+			notifications++
+			continue
+		}
+
+		// 按id找到对应的响应
+		byID, decoded := responses[call.entry]
+		if !decoded {
+			byID = jsonRPCResponses(responseBodyText(call.entry))
+			responses[call.entry] = byID
+		}
+		response := byID[request.id]
+
+		failed := call.entry.Response.Status == 0 || call.entry.Response.Status >= 400
+		rpcError, _ := response["error"].(map[string]interface{})
+		if rpcError != nil || failed {
+			method.Errors++
+			if method.ErrorCodes == nil {
+				method.ErrorCodes = make(map[string]int)
+			}
+			description := strings.TrimSpace(fmt.Sprintf("HTTP %d %s", call.entry.Response.Status, call.entry.Response.StatusText))
+			if rpcError != nil {
+				message, _ := rpcError["message"].(string)
+				description = strings.TrimSpace(fmt.Sprintf("%v %s", rpcError["code"], ua.redactValue(message)))
+			}
+			if _, seen := method.ErrorCodes[description]; seen || len(method.ErrorCodes) < maxJSONRPCErrors {
+				method.ErrorCodes[description]++
+			}
+		}
+		if result, ok := response["result"]; ok && rpcError == nil {
+			method.result.add(result)
+		}
+	}
+
+	result := &JSONRPCAnalysis{Requests: c.requests, Batched: c.batched, Notifications: notifications}
+	for _, key := range order {
+		method := methods[key]
+		method.Latency = computeLatencyStats(method.durations)
+		result.Methods = append(result.Methods, *method)
+	}
+	sort.SliceStable(result.Methods, func(i, j int) bool {
+		return result.Methods[i].Calls > result.Methods[j].Calls
+	})
+	result.GoTypes, result.GoClient = generateJSONRPCClient(result.Methods)
+	return result
+}
+
+// 解析响应体（单个或批量），按id索引
+func jsonRPCResponses(body string) map[string]map[string]interface{} {
+	decoded, err := decodeJSONWithNumbers(strings.TrimSpace(body))
+	if err != nil {
+		return nil
+	}
+	items, isBatch := decoded.([]interface{})
+	if !isBatch {
+		items = []interface{}{decoded}
+	}
+	byID := make(map[string]map[string]interface{})
+	for _, item := range items {
+		if object, ok := item.(map[string]interface{}); ok {
+			byID[jsonRPCID(object["id"])] = object
+		}
+	}
+	return byID
+}
+
+// 脱敏后的参数示例
+func (ua *UniversalHARAnalyzer) redactJSONRPCParams(params interface{}) string {
+	if named, ok := params.(map[string]interface{}); ok {
+		return ua.redactGraphQLVariables(named)
+	}
+	data, err := json.Marshal(params)
+	if err != nil {
+		return ""
+	}
+	return ua.redactValue(string(data))
+}
+
+// 为每个方法生成参数/结果类型和Go客户端方法
+func generateJSONRPCClient(methods []JSONRPCMethod) ([]string, string) {
+	generator := newGoTypeGenerator()
+	var code strings.Builder
+	code.WriteString(`type RPCClient struct {
+	Endpoint   string
+	HTTPClient *http.Client
+	nextID     int64
+}
+
+type RPCError struct {
+	Code    int             ` + "`json:\"code\"`" + `
+	Message string          ` + "`json:\"message\"`" + `
+	Data    json.RawMessage ` + "`json:\"data,omitempty\"`" + `
+}
+
+func (e *RPCError) Error() string {
+	return fmt.Sprintf("json-rpc error %d: %s", e.Code, e.Message)
+}
+
+func (c *RPCClient) post(ctx context.Context, request map[string]interface{}) (*http.Response, error) {
+	body, err := json.Marshal(request)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.Endpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	return c.HTTPClient.Do(req)
+}
+
+func (c *RPCClient) notify(ctx context.Context, method string, params interface{}) error {
+	resp, err := c.post(ctx, map[string]interface{}{"jsonrpc": "2.0", "method": method, "params": params})
+	if err != nil {
+		return err
+	}
+	return resp.Body.Close()
+}
+
+func (c *RPCClient) call(ctx context.Context, method string, params, result interface{}) error {
+	c.nextID++
+	resp, err := c.post(ctx, map[string]interface{}{"jsonrpc": "2.0", "id": c.nextID, "method": method, "params": params})
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	var response struct {
+		Result json.RawMessage ` + "`json:\"result\"`" + `
+		Error  *RPCError       ` + "`json:\"error\"`" + `
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return err
+	}
+	if response.Error != nil {
+		return response.Error
+	}
+	return json.Unmarshal(response.Result, result)
+}
+`)
+
+	used := make(map[string]bool)
+	for _, method := range methods {
+		name := goTypeName(method.Name)
+		if used[name] {
+			continue
+		}
+		used[name] = true
+
+		paramsType := "interface{}"
+		switch {
+		case method.ParamsStyle == "positional":
+			paramsType = "[]interface{}"
+		case method.params.samples > 0:
+			paramsType = generator.generate(name+"Params", method.params)
+		}
+		code.WriteString("\n// " + name + " " + T("codegen.rpc_method", method.Name, method.Endpoint, method.Calls) + "\n")
+
+		// 只以通知方式调用的方法没有响应
+		if method.Notifications == method.Calls {
+			fmt.Fprintf(&code, "func (c *RPCClient) %s(ctx context.Context, params %s) error {\n", name, paramsType)
+			fmt.Fprintf(&code, "\treturn c.notify(ctx, %q, params)\n}\n", method.Name)
+			continue
+		}
+
+		resultType := "json.RawMessage"
+		if method.result.samples > 0 {
+			resultType = generator.generate(name+"Result", method.result)
+		}
+		fmt.Fprintf(&code, "func (c *RPCClient) %s(ctx context.Context, params %s) (%s, error) {\n", name, paramsType, resultType)
+		fmt.Fprintf(&code, "\tvar result %s\n", resultType)
+		fmt.Fprintf(&code, "\terr := c.call(ctx, %q, params, &result)\n", method.Name)
+		code.WriteString("\treturn result, err\n}\n")
+	}
+	return generator.code(), strings.TrimSuffix(code.String(), "\n")
+}
@@ -0,0 +1,465 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"net/http/cookiejar"
+	"net/url"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// 压测结果
+type LoadTestResult struct {
+	Metadata struct {
+		FileName       string    `json:"fileName"`
+		Target         string    `json:"target"`
+		StartTime      time.Time `json:"startTime"`
+		Duration       string    `json:"duration"`
+		VUs            int       `json:"vus"`
+		ThinkTimeScale float64   `json:"thinkTimeScale"`
+		Iterations     int       `json:"iterations"`
+		TotalRequests  int       `json:"totalRequests"`
+		TotalErrors    int       `json:"totalErrors"`
+		Throughput     float64   `json:"throughput"`            // 请求/秒
+		Interrupted    bool      `json:"interrupted,omitempty"` // 在达到设定时长前被中断（结果不完整）
+	} `json:"metadata"`
+
+	Latency   LatencyStats        `json:"latency"`
+	Endpoints []LoadEndpointStats `json:"endpoints"`
+}
+
+// 延迟统计（毫秒）
+type LatencyStats struct {
+	Min  float64 `json:"min"`
+	Mean float64 `json:"mean"`
+	P50  float64 `json:"p50"`
+	P90  float64 `json:"p90"`
+	P95  float64 `json:"p95"`
+	P99  float64 `json:"p99"`
+	Max  float64 `json:"max"`
+}
+
+// 单个端点的压测统计
+type LoadEndpointStats struct {
+	Method      string         `json:"method"`
+	Path        string         `json:"path"`
+	Expected    int            `json:"expectedStatus"` // HAR中记录的状态码（同名步骤按预期状态码分开统计）
+	Requests    int            `json:"requests"`
+	Errors      int            `json:"errors"`
+	ErrorRate   float64        `json:"errorRate"`
+	Throughput  float64        `json:"throughput"`
+	Latency     LatencyStats   `json:"latency"`
+	StatusCodes map[string]int `json:"statusCodes"` // 状态码及出现次数（"error"表示网络错误）
+}
+
+// 压测配置
+type LoadTestOptions struct {
+	Target         *url.URL
+	VUs            int
+	Duration       time.Duration
+	ThinkTimeScale float64
+	Timeout        time.Duration
+}
+
+// 压测过程中收集的端点数据
+type loadEndpointCollector struct {
+	method      string
+	path        string
+	expected    int
+	latencies   []float64
+	errors      int
+	statusCodes map[string]int
+}
+
+// HAR压测执行器
+type HARLoadRunner struct {
+	script  *LoadScript
+	options LoadTestOptions
+
+	mu         sync.Mutex
+	collectors map[string]*loadEndpointCollector
+	iterations int
+}
+
+// 创建压测执行器
+func NewHARLoadRunner(script *LoadScript, options LoadTestOptions) *HARLoadRunner {
+	return &HARLoadRunner{
+		script:     script,
+		options:    options,
+		collectors: make(map[string]*loadEndpointCollector),
+	}
+}
+
+// 执行压测
+func (lr *HARLoadRunner) Run(ctx context.Context) *LoadTestResult {
+	ctx, cancel := context.WithTimeout(ctx, lr.options.Duration)
+	defer cancel()
+
+	startTime := time.Now()
+	var wg sync.WaitGroup
+	for i := 0; i < lr.options.VUs; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for ctx.Err() == nil {
+				lr.runIteration(ctx)
+			}
+		}()
+	}
+	wg.Wait()
+
+	return lr.buildResult(startTime, time.Since(startTime))
+}
+
+// 虚拟用户执行一轮完整的请求序列
+func (lr *HARLoadRunner) runIteration(ctx context.Context) {
+	jar, _ := cookiejar.New(nil)
+	client := &http.Client{
+		Jar:     jar,
+		Timeout: lr.options.Timeout,
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+	vars := make(map[string]string)
+
+	for _, step := range lr.script.Steps {
+		thinkTime := time.Duration(float64(step.ThinkTime) * lr.options.ThinkTimeScale)
+		if thinkTime > 0 {
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(thinkTime):
+			}
+		}
+
+		req, err := lr.buildRequest(ctx, step, vars)
+		if err != nil {
+			lr.record(step, 0, 0, err)
+			continue
+		}
+
+		start := time.Now()
+		resp, err := client.Do(req)
+		if err != nil {
+			// 压测结束时被取消的请求不计入统计
+			if ctx.Err() != nil {
+				return
+			}
+			lr.record(step, time.Since(start), 0, err)
+			continue
+		}
+		data, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		lr.record(step, time.Since(start), resp.StatusCode, nil)
+
+		for _, extract := range step.Extracts {
+			if value, ok := lookupJSONPath(data, extract.Path); ok {
+				vars[extract.Var] = value
+			}
+		}
+	}
+
+	lr.mu.Lock()
+	lr.iterations++
+	lr.mu.Unlock()
+}
+
+// 根据脚本步骤构建指向目标地址的请求
+func (lr *HARLoadRunner) buildRequest(ctx context.Context, step LoadScriptStep, vars map[string]string) (*http.Request, error) {
+	reqURL, err := url.Parse(expandLoadScriptVars(step.URL, vars))
+	if err != nil {
+		return nil, err
+	}
+	// 保留原始的转义形式（如 %2F），目标地址的路径作为前缀
+	escapedPath := reqURL.EscapedPath()
+	reqURL.Scheme = lr.options.Target.Scheme
+	reqURL.Host = lr.options.Target.Host
+	reqURL.Path = strings.TrimSuffix(lr.options.Target.Path, "/") + reqURL.Path
+	reqURL.RawPath = strings.TrimSuffix(lr.options.Target.EscapedPath(), "/") + escapedPath
+
+	var body io.Reader
+	if step.Body != "" {
+		body = strings.NewReader(expandLoadScriptVars(step.Body, vars))
+	}
+	req, err := http.NewRequestWithContext(ctx, step.Method, reqURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+	for _, header := range step.Headers {
+		req.Header.Set(header.Name, expandLoadScriptVars(header.Value, vars))
+	}
+	return req, nil
+}
+
+// 记录单次请求结果（状态码与HAR中记录的不一致视为错误）
+func (lr *HARLoadRunner) record(step LoadScriptStep, latency time.Duration, status int, err error) {
+	lr.mu.Lock()
+	defer lr.mu.Unlock()
+
+	// 同名步骤的预期状态码可能不同（如 http 的301和 https 的200），按名称和预期状态码分组
+	key := fmt.Sprintf("%s %d", step.Name, step.Status)
+	collector, exists := lr.collectors[key]
+	if !exists {
+		collector = &loadEndpointCollector{
+			method:      step.Method,
+			path:        strings.TrimPrefix(step.Name, step.Method+" "),
+			expected:    step.Status,
+			statusCodes: make(map[string]int),
+		}
+		lr.collectors[key] = collector
+	}
+
+	collector.latencies = append(collector.latencies, float64(latency)/float64(time.Millisecond))
+	if err != nil {
+		collector.statusCodes["error"]++
+		collector.errors++
+		return
+	}
+	collector.statusCodes[fmt.Sprintf("%d", status)]++
+	if status != step.Status {
+		collector.errors++
+	}
+}
+
+// 汇总压测结果
+func (lr *HARLoadRunner) buildResult(startTime time.Time, elapsed time.Duration) *LoadTestResult {
+	lr.mu.Lock()
+	defer lr.mu.Unlock()
+
+	result := &LoadTestResult{}
+	result.Metadata.FileName = lr.script.Source
+	result.Metadata.Target = lr.options.Target.String()
+	result.Metadata.StartTime = startTime
+	result.Metadata.Duration = elapsed.Round(time.Millisecond).String()
+	result.Metadata.VUs = lr.options.VUs
+	result.Metadata.ThinkTimeScale = lr.options.ThinkTimeScale
+	result.Metadata.Iterations = lr.iterations
+
+	var allLatencies []float64
+	for _, collector := range lr.collectors {
+		endpoint := LoadEndpointStats{
+			Method:      collector.method,
+			Path:        collector.path,
+			Expected:    collector.expected,
+			Requests:    len(collector.latencies),
+			Errors:      collector.errors,
+			Throughput:  float64(len(collector.latencies)) / elapsed.Seconds(),
+			Latency:     computeLatencyStats(collector.latencies),
+			StatusCodes: collector.statusCodes,
+		}
+		if endpoint.Requests > 0 {
+			endpoint.ErrorRate = float64(endpoint.Errors) / float64(endpoint.Requests)
+		}
+		result.Endpoints = append(result.Endpoints, endpoint)
+
+		result.Metadata.TotalRequests += endpoint.Requests
+		result.Metadata.TotalErrors += endpoint.Errors
+		allLatencies = append(allLatencies, collector.latencies...)
+	}
+
+	sort.Slice(result.Endpoints, func(i, j int) bool {
+		return result.Endpoints[i].Requests > result.Endpoints[j].Requests
+	})
+
+	result.Metadata.Throughput = float64(result.Metadata.TotalRequests) / elapsed.Seconds()
+	result.Latency = computeLatencyStats(allLatencies)
+	return result
+}
+
+// 计算延迟统计
+func computeLatencyStats(values []float64) LatencyStats {
+	if len(values) == 0 {
+		return LatencyStats{}
+	}
+
+	sorted := append([]float64{}, values...)
+	sort.Float64s(sorted)
+
+	var sum float64
+	for _, v := range sorted {
+		sum += v
+	}
+
+	return LatencyStats{
+		Min:  roundMillis(sorted[0]),
+		Mean: roundMillis(sum / float64(len(sorted))),
+		P50:  roundMillis(percentile(sorted, 0.50)),
+		P90:  roundMillis(percentile(sorted, 0.90)),
+		P95:  roundMillis(percentile(sorted, 0.95)),
+		P99:  roundMillis(percentile(sorted, 0.99)),
+		Max:  roundMillis(sorted[len(sorted)-1]),
+	}
+}
+
+// 计算已排序数据的百分位数（线性插值）
+func percentile(sorted []float64, p float64) float64 {
+	if len(sorted) == 0 {
+		return 0
+	}
+	rank := p * float64(len(sorted)-1)
+	lower := int(math.Floor(rank))
+	upper := int(math.Ceil(rank))
+	return sorted[lower] + (sorted[upper]-sorted[lower])*(rank-float64(lower))
+}
+
+func roundMillis(v float64) float64 {
+	return math.Round(v*100) / 100
+}
+
+// 替换占位符为关联变量的值
+func expandLoadScriptVars(text string, vars map[string]string) string {
+	parts := strings.Split(text, loadScriptVarMark)
+	for i := 1; i < len(parts); i += 2 {
+		parts[i] = vars[parts[i]]
+	}
+	return strings.Join(parts, "")
+}
+
+// 压测结果和报告的文件类型（x_load.json、x_load.md）
+const loadKind = "load"
+
+// 所有端点合并的状态码统计
+func (r *LoadTestResult) StatusCodes() map[string]int {
+	statusCodes := make(map[string]int)
+	for _, endpoint := range r.Endpoints {
+		for code, count := range endpoint.StatusCodes {
+			statusCodes[code] += count
+		}
+	}
+	return statusCodes
+}
+
+// 错误率百分比
+func (e LoadEndpointStats) ErrorPercent() float64 {
+	return e.ErrorRate * 100
+}
+
+// 保存压测结果（JSON和 load.md.tmpl 渲染的报告，按 -naming、-output-mode 命名并写入输出清单）
+func (ua *UniversalHARAnalyzer) saveLoadTestResult(filePath string, result *LoadTestResult) error {
+	if err := os.MkdirAll(ua.outputDir, 0755); err != nil {
+		return fmt.Errorf("%s: %w", T("err.create_output_dir"), err)
+	}
+	baseName, err := ua.outputBaseName(filePath)
+	if err != nil {
+		return err
+	}
+
+	jsonData, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return err
+	}
+	jsonFile := ua.artifactPath(baseName, loadKind, ".json")
+	if err := os.WriteFile(jsonFile, jsonData, 0644); err != nil {
+		return err
+	}
+	if err := ua.recordArtifact(jsonFile, loadKind, filePath); err != nil {
+		return err
+	}
+
+	reportFile := ua.artifactPath(baseName, loadKind, ".md")
+	if err := ua.writeBuiltinTemplate("load.md.tmpl", reportFile, result); err != nil {
+		return err
+	}
+	if err := ua.recordArtifact(reportFile, loadKind, filePath); err != nil {
+		return err
+	}
+	return ua.writeManifest()
+}
+
+// 压测命令
+func runLoadCommand(args []string) error {
+	analyzer := NewUniversalHARAnalyzer()
+
+	flags := flag.NewFlagSet("load", flag.ExitOnError)
+	target := flags.String("target", "", T("flag.target"))
+	vus := flags.Int("vus", 10, T("flag.vus"))
+	duration := flags.Duration("duration", time.Minute, T("flag.duration"))
+	scale := flags.Float64("scale", 1.0, T("flag.scale"))
+	maxThink := flags.Duration("max-think", 30*time.Second, T("flag.max_think"))
+	timeout := flags.Duration("timeout", 30*time.Second, T("flag.timeout"))
+	flags.StringVar(&analyzer.outputDir, "o", analyzer.outputDir, T("flag.output_dir"))
+	filter := flags.String("filter", "", T("flag.filter"))
+	excludeStatic := flags.Bool("exclude-static", false, T("flag.exclude_static"))
+	configPath := addConfigFlag(flags)
+	addOutputNamingFlags(flags)
+	addLanguageFlag(flags)
+	flags.Parse(args)
+
+	if err := analyzer.Configure(flags, *configPath, *filter, *excludeStatic); err != nil {
+		return err
+	}
+
+	if *target == "" {
+		return fmt.Errorf("%s", T("load.target_required"))
+	}
+	targetURL, err := url.Parse(*target)
+	if err != nil || targetURL.Scheme == "" || targetURL.Host == "" {
+		return fmt.Errorf("%s", T("load.invalid_target", *target))
+	}
+	if *vus <= 0 {
+		return fmt.Errorf("%s", T("load.invalid_vus"))
+	}
+	if *scale < 0 {
+		return fmt.Errorf("%s", T("load.invalid_scale"))
+	}
+	if flags.NArg() != 1 {
+		return fmt.Errorf("%s", T("load.har_required"))
+	}
+
+	filePath := flags.Arg(0)
+	analyzer.beginOutput(harFileRoots(flags.Args())...)
+	harFile, err := analyzer.LoadHARFile(filePath)
+	if err != nil {
+		return err
+	}
+
+	script := analyzer.BuildLoadScript(harFile, filepath.Base(filePath), *maxThink)
+	if len(script.Steps) == 0 {
+		return fmt.Errorf("%s", T("load.no_requests"))
+	}
+
+	// 所有请求都发往 -target，包含多个主机（CDN、统计服务等）时提示用 -filter 选择
+	if hosts := script.Hosts(); len(hosts) > 1 {
+		fmt.Println("⚠️ " + T("load.multiple_hosts", strings.Join(hosts, ", "), *target))
+	}
+	fmt.Println("🚀 " + T("load.start", filepath.Base(filePath), targetURL, *vus, *duration))
+
+	runner := NewHARLoadRunner(script, LoadTestOptions{
+		Target:         targetURL,
+		VUs:            *vus,
+		Duration:       *duration,
+		ThinkTimeScale: *scale,
+		Timeout:        *timeout,
+	})
+	// 按 Ctrl+C 中断时停止压测并保存已完成部分的结果
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	result := runner.Run(ctx)
+	if ctx.Err() != nil {
+		result.Metadata.Interrupted = true
+		fmt.Println("\n🛑 " + T("load.interrupted", result.Metadata.Duration))
+	}
+
+	fmt.Println("✅ " + T("load.done",
+		result.Metadata.TotalRequests, result.Metadata.TotalErrors,
+		result.Metadata.Throughput, result.Latency.P95))
+
+	if err := analyzer.saveLoadTestResult(filePath, result); err != nil {
+		return fmt.Errorf("%s: %w", T("err.save_result"), err)
+	}
+	fmt.Println("📂 " + T("common.view_results", analyzer.outputDir))
+	return nil
+}
@@ -0,0 +1,634 @@
+package main
+
+import (
+	_ "embed"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// 压测脚本中的变量占位符分隔符，生成脚本时替换为对应语言的变量引用
+const loadScriptVarMark = "\x00"
+
+// 关联变量的最短取值长度，过短的值容易误匹配
+const minCorrelationValueLen = 8
+
+// 压测脚本
+type LoadScript struct {
+	Source string
+	Steps  []LoadScriptStep
+	Vars   []string
+}
+
+// 脚本中请求的主机（按首次出现的顺序）
+func (s *LoadScript) Hosts() []string {
+	var hosts []string
+	for _, step := range s.Steps {
+		if host := normalizeURL(step.URL).Authority(); !containsString(hosts, host) {
+			hosts = append(hosts, host)
+		}
+	}
+	return hosts
+}
+
+// 压测脚本中的单个请求步骤
+type LoadScriptStep struct {
+	Name      string
+	Method    string
+	URL       string
+	Headers   []HARNameValue
+	Body      string
+	Status    int
+	ThinkTime time.Duration
+	Extracts  []LoadScriptExtract
+}
+
+// 从响应JSON中提取关联变量
+type LoadScriptExtract struct {
+	Var  string
+	Path []string
+}
+
+// 压测脚本不需要携带的请求头（由客户端自动处理）
+var loadScriptSkippedHeaders = map[string]bool{
+	"host":              true,
+	"content-length":    true,
+	"connection":        true,
+	"cookie":            true,
+	"accept-encoding":   true,
+	"transfer-encoding": true,
+}
+
+// 关联变量候选值
+type correlationCandidate struct {
+	value string
+	step  int
+	path  []string
+}
+
+// 根据HAR文件构建压测脚本
+func (ua *UniversalHARAnalyzer) BuildLoadScript(harFile *UniversalHARFile, source string, maxThink time.Duration) *LoadScript {
+	script := &LoadScript{Source: source}
+
+	entries := ua.sortedHTTPEntries(ua.selectEntries(harFile.Log.Entries))
+
+	var candidates []*correlationCandidate
+	candidateSet := make(map[string]bool)
+	varByValue := make(map[string]string)
+	varNames := make(map[string]bool)
+	var requestHistory strings.Builder
+
+	var prevStart time.Time
+	var prevTime float64
+	for i, entry := range entries {
+		step := LoadScriptStep{
+			Name:   fmt.Sprintf("%s %s", entry.Request.Method, ua.extractPath(entry.Request.URL)),
+			Method: entry.Request.Method,
+			URL:    entry.Request.URL,
+			Body:   entry.Request.PostData.Text,
+			Status: entry.Response.Status,
+		}
+		for _, header := range entry.Request.Headers {
+			if strings.HasPrefix(header.Name, ":") || loadScriptSkippedHeaders[strings.ToLower(header.Name)] {
+				continue
+			}
+			step.Headers = append(step.Headers, header)
+		}
+
+		// 思考时间 = 本次请求开始时间 - 上次请求结束时间
+		if start, err := time.Parse(time.RFC3339, entry.StartedDateTime); err == nil {
+			if i > 0 && !prevStart.IsZero() {
+				gap := start.Sub(prevStart) - time.Duration(prevTime*float64(time.Millisecond))
+				if gap < 0 {
+					gap = 0
+				}
+				if maxThink > 0 && gap > maxThink {
+					gap = maxThink
+				}
+				step.ThinkTime = gap.Round(time.Millisecond)
+			}
+			prevStart = start
+		}
+		prevTime = entry.Time
+
+		rawRequest := step.URL + "\n" + step.Body
+		for _, header := range step.Headers {
+			rawRequest += "\n" + header.Value
+		}
+
+		// 替换请求中出现的关联值（长值优先，避免部分替换）
+		for _, candidate := range candidates {
+			if !strings.Contains(rawRequest, candidate.value) {
+				continue
+			}
+			varName, exists := varByValue[candidate.value]
+			if !exists {
+				varName = ua.uniqueVarName(candidate.path, varNames)
+				varByValue[candidate.value] = varName
+				script.Vars = append(script.Vars, varName)
+				producer := &script.Steps[candidate.step]
+				producer.Extracts = append(producer.Extracts, LoadScriptExtract{Var: varName, Path: candidate.path})
+			}
+			placeholder := loadScriptVarMark + varName + loadScriptVarMark
+			step.URL = strings.ReplaceAll(step.URL, candidate.value, placeholder)
+			step.Body = strings.ReplaceAll(step.Body, candidate.value, placeholder)
+			for j := range step.Headers {
+				step.Headers[j].Value = strings.ReplaceAll(step.Headers[j].Value, candidate.value, placeholder)
+			}
+		}
+
+		requestHistory.WriteString(rawRequest)
+		script.Steps = append(script.Steps, step)
+
+		// 收集响应中的候选关联值，忽略之前请求中已出现过的值（非服务端生成）
+		var body interface{}
+		if strings.Contains(entry.Response.Content.MimeType, "json") &&
+			json.Unmarshal([]byte(entry.Response.Content.Text), &body) == nil {
+			history := requestHistory.String()
+			ua.walkJSONStrings(body, nil, func(path []string, value string) {
+				if len(value) < minCorrelationValueLen || candidateSet[value] || strings.Contains(history, value) {
+					return
+				}
+				candidateSet[value] = true
+				candidates = append(candidates, &correlationCandidate{value: value, step: i, path: path})
+			})
+			sort.SliceStable(candidates, func(a, b int) bool {
+				return len(candidates[a].value) > len(candidates[b].value)
+			})
+		}
+	}
+
+	return script
+}
+
+// 按开始时间排序并过滤出HTTP(S)请求（解析时间后比较，时区偏移和小数位数不同的时间也能正确排序；无法解析的时间排在最前）
+func (ua *UniversalHARAnalyzer) sortedHTTPEntries(entries []HAREntry) []HAREntry {
+	var result []HAREntry
+	var started []time.Time
+	for _, entry := range entries {
+		lowerURL := strings.ToLower(entry.Request.URL)
+		if strings.HasPrefix(lowerURL, "http://") || strings.HasPrefix(lowerURL, "https://") {
+			start, _ := time.Parse(time.RFC3339Nano, entry.StartedDateTime)
+			result = append(result, entry)
+			started = append(started, start)
+		}
+	}
+	order := make([]int, len(result))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return started[order[i]].Before(started[order[j]])
+	})
+	sorted := make([]HAREntry, len(result))
+	for i, index := range order {
+		sorted[i] = result[index]
+	}
+	return sorted
+}
+
+// 遍历JSON中的所有字符串值
+func (ua *UniversalHARAnalyzer) walkJSONStrings(data interface{}, path []string, fn func(path []string, value string)) {
+	switch v := data.(type) {
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			ua.walkJSONStrings(v[key], append(append([]string{}, path...), key), fn)
+		}
+	case []interface{}:
+		for i, item := range v {
+			ua.walkJSONStrings(item, append(append([]string{}, path...), strconv.Itoa(i)), fn)
+		}
+	case string:
+		fn(path, v)
+	}
+}
+
+// 根据JSON路径生成唯一的变量名
+func (ua *UniversalHARAnalyzer) uniqueVarName(path []string, used map[string]bool) string {
+	base := "value"
+	for i := len(path) - 1; i >= 0; i-- {
+		if _, err := strconv.Atoi(path[i]); err != nil {
+			base = path[i]
+			break
+		}
+	}
+
+	var name strings.Builder
+	for _, r := range base {
+		if r == '_' || (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9' && name.Len() > 0) {
+			name.WriteRune(r)
+		}
+	}
+	if name.Len() == 0 {
+		name.WriteString("value")
+	}
+
+	varName := name.String()
+	for i := 2; used[varName]; i++ {
+		varName = fmt.Sprintf("%s%d", name.String(), i)
+	}
+	used[varName] = true
+	return varName
+}
+
+// 生成k6压测脚本
+func (ua *UniversalHARAnalyzer) GenerateK6Script(script *LoadScript, vus int, duration time.Duration) string {
+	var js strings.Builder
+
+	js.WriteString("// " + T("codegen.generated_from", script.Source) + "\n")
+	js.WriteString("import http from 'k6/http';\n")
+	js.WriteString("import { check, sleep } from 'k6';\n\n")
+
+	js.WriteString("export const options = {\n")
+	js.WriteString(fmt.Sprintf("  vus: %d,\n", vus))
+	js.WriteString(fmt.Sprintf("  duration: '%s',\n", duration))
+	js.WriteString("};\n\n")
+
+	js.WriteString("// " + T("codegen.extract_comment") + "\n")
+	js.WriteString("function extract(res, path) {\n")
+	js.WriteString("  let value;\n")
+	js.WriteString("  try {\n")
+	js.WriteString("    value = res.json();\n")
+	js.WriteString("  } catch (e) {\n")
+	js.WriteString("    return undefined;\n")
+	js.WriteString("  }\n")
+	js.WriteString("  for (const key of path) {\n")
+	js.WriteString("    if (value === null || value === undefined) {\n")
+	js.WriteString("      return undefined;\n")
+	js.WriteString("    }\n")
+	js.WriteString("    value = value[key];\n")
+	js.WriteString("  }\n")
+	js.WriteString("  return value;\n")
+	js.WriteString("}\n\n")
+
+	js.WriteString("export default function () {\n")
+	js.WriteString("  const vars = {};\n")
+	js.WriteString("  let res;\n")
+
+	for i, step := range script.Steps {
+		js.WriteString(fmt.Sprintf("\n  // %d. %s\n", i+1, step.Name))
+		if step.ThinkTime > 0 {
+			js.WriteString(fmt.Sprintf("  sleep(%.3f);\n", step.ThinkTime.Seconds()))
+		}
+
+		body := "null"
+		if step.Body != "" {
+			body = ua.jsTemplateLiteral(step.Body)
+		}
+
+		js.WriteString(fmt.Sprintf("  res = http.request(%s, %s, %s, {\n", strconv.Quote(step.Method), ua.jsTemplateLiteral(step.URL), body))
+		js.WriteString("    headers: {\n")
+		for _, header := range step.Headers {
+			js.WriteString(fmt.Sprintf("      %s: %s,\n", strconv.Quote(header.Name), ua.jsTemplateLiteral(header.Value)))
+		}
+		js.WriteString("    },\n")
+		js.WriteString(fmt.Sprintf("    tags: { name: %s },\n", strconv.Quote(step.Name)))
+		js.WriteString("    redirects: 0,\n")
+		js.WriteString("  });\n")
+		js.WriteString(fmt.Sprintf("  check(res, { %s: (r) => r.status === %d });\n",
+			strconv.Quote(fmt.Sprintf("%s status %d", step.Name, step.Status)), step.Status))
+
+		for _, extract := range step.Extracts {
+			js.WriteString(fmt.Sprintf("  vars.%s = extract(res, %s);\n", extract.Var, ua.jsStringArray(extract.Path)))
+		}
+	}
+
+	js.WriteString("}\n")
+	return js.String()
+}
+
+// 转换为JavaScript模板字符串，占位符替换为vars引用
+func (ua *UniversalHARAnalyzer) jsTemplateLiteral(text string) string {
+	parts := strings.Split(text, loadScriptVarMark)
+
+	var literal strings.Builder
+	literal.WriteString("`")
+	for i, part := range parts {
+		if i%2 == 1 {
+			literal.WriteString("${vars." + part + "}")
+			continue
+		}
+		part = strings.ReplaceAll(part, "\\", "\\\\")
+		part = strings.ReplaceAll(part, "`", "\\`")
+		part = strings.ReplaceAll(part, "${", "\\${")
+		literal.WriteString(part)
+	}
+	literal.WriteString("`")
+	return literal.String()
+}
+
+// 转换为JavaScript字符串数组
+func (ua *UniversalHARAnalyzer) jsStringArray(items []string) string {
+	quoted := make([]string, len(items))
+	for i, item := range items {
+		quoted[i] = strconv.Quote(item)
+	}
+	return "[" + strings.Join(quoted, ", ") + "]"
+}
+
+// 生成自包含的Go压测程序
+func (ua *UniversalHARAnalyzer) GenerateGoLoadGenerator(script *LoadScript, vus int, duration time.Duration) string {
+	var code strings.Builder
+
+	code.WriteString("// " + T("codegen.generated_from", script.Source) + "\n")
+	code.WriteString("// " + T("codegen.run_hint", "go run main.go -vus 10 -duration 1m") + "\n\n")
+	code.WriteString("package main\n\n")
+	code.WriteString(goLoadGeneratorImports)
+
+	code.WriteString(fmt.Sprintf("const (\n\tdefaultVUs      = %d\n\tdefaultDuration = %d // %s\n)\n\n", vus, int64(duration), duration))
+
+	code.WriteString("var steps = []step{\n")
+	for _, step := range script.Steps {
+		code.WriteString("\t{\n")
+		code.WriteString(fmt.Sprintf("\t\tName:      %s,\n", strconv.Quote(step.Name)))
+		code.WriteString(fmt.Sprintf("\t\tMethod:    %s,\n", strconv.Quote(step.Method)))
+		code.WriteString(fmt.Sprintf("\t\tURL:       %s,\n", strconv.Quote(step.URL)))
+		if len(step.Headers) > 0 {
+			code.WriteString("\t\tHeaders: [][2]string{\n")
+			for _, header := range step.Headers {
+				code.WriteString(fmt.Sprintf("\t\t\t{%s, %s},\n", strconv.Quote(header.Name), strconv.Quote(header.Value)))
+			}
+			code.WriteString("\t\t},\n")
+		}
+		if step.Body != "" {
+			code.WriteString(fmt.Sprintf("\t\tBody:      %s,\n", strconv.Quote(step.Body)))
+		}
+		code.WriteString(fmt.Sprintf("\t\tStatus:    %d,\n", step.Status))
+		if step.ThinkTime > 0 {
+			code.WriteString(fmt.Sprintf("\t\tThinkTime: %d * time.Millisecond,\n", step.ThinkTime.Milliseconds()))
+		}
+		if len(step.Extracts) > 0 {
+			code.WriteString("\t\tExtracts: []extract{\n")
+			for _, extract := range step.Extracts {
+				code.WriteString(fmt.Sprintf("\t\t\t{Var: %s, Path: []string{%s}},\n",
+					strconv.Quote(extract.Var), goStringList(extract.Path)))
+			}
+			code.WriteString("\t\t},\n")
+		}
+		code.WriteString("\t},\n")
+	}
+	code.WriteString("}\n")
+
+	code.WriteString(localizeCodeTemplate(goLoadGeneratorRuntime))
+	code.WriteString(goLoadGeneratorJSONPath())
+	return code.String()
+}
+
+// 转换为Go字符串切片字面量的元素列表
+func goStringList(items []string) string {
+	quoted := make([]string, len(items))
+	for i, item := range items {
+		quoted[i] = strconv.Quote(item)
+	}
+	return strings.Join(quoted, ", ")
+}
+
+// 生成程序与压测运行器共用的JSON取值实现
+//
+//go:embed HarJSONPath.go
+var jsonPathSource string
+
+// 生成程序中的JSON取值函数（取自 HarJSONPath.go，去掉包声明和导入）
+func goLoadGeneratorJSONPath() string {
+	source := strings.ReplaceAll(jsonPathSource, "\r\n", "\n")
+	source = source[strings.Index(source, "func lookupJSONPath"):]
+	return "\n// " + T("codegen.extract_comment") + "\n" + source
+}
+
+const goLoadGeneratorImports = `import (
+	_ "embed"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/cookiejar"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+`
+
+const goLoadGeneratorRuntime = `
+type extract struct {
+	Var  string
+	Path []string
+}
+
+type step struct {
+	Name      string
+	Method    string
+	URL       string
+	Headers   [][2]string
+	Body      string
+	Status    int
+	ThinkTime time.Duration
+	Extracts  []extract
+}
+
+type stepStats struct {
+	mu        sync.Mutex
+	calls     int
+	errors    int
+	latencies []time.Duration
+}
+
+func main() {
+	vus := flag.Int("vus", defaultVUs, "{{flag.vus}}")
+	duration := flag.Duration("duration", time.Duration(defaultDuration), "{{flag.duration}}")
+	flag.Parse()
+
+	stats := make([]stepStats, len(steps))
+	deadline := time.Now().Add(*duration)
+
+	var wg sync.WaitGroup
+	for i := 0; i < *vus; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for time.Now().Before(deadline) {
+				runIteration(deadline, stats)
+			}
+		}()
+	}
+	wg.Wait()
+
+	printReport(stats, *duration)
+}
+
+// {{codegen.run_iteration_comment}}
+func runIteration(deadline time.Time, stats []stepStats) {
+	jar, _ := cookiejar.New(nil)
+	client := &http.Client{
+		Jar:     jar,
+		Timeout: 30 * time.Second,
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+	vars := make(map[string]string)
+
+	for i, s := range steps {
+		if time.Now().Add(s.ThinkTime).After(deadline) {
+			return
+		}
+		time.Sleep(s.ThinkTime)
+
+		var body io.Reader
+		if s.Body != "" {
+			body = strings.NewReader(expand(s.Body, vars))
+		}
+		req, err := http.NewRequest(s.Method, expand(s.URL, vars), body)
+		if err != nil {
+			stats[i].record(0, false)
+			continue
+		}
+		for _, h := range s.Headers {
+			req.Header.Set(h[0], expand(h[1], vars))
+		}
+
+		start := time.Now()
+		resp, err := client.Do(req)
+		if err != nil {
+			stats[i].record(time.Since(start), false)
+			continue
+		}
+		data, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		stats[i].record(time.Since(start), resp.StatusCode == s.Status)
+
+		for _, e := range s.Extracts {
+			if value, ok := lookupJSONPath(data, e.Path); ok {
+				vars[e.Var] = value
+			}
+		}
+	}
+}
+
+// {{codegen.expand_comment}}
+func expand(text string, vars map[string]string) string {
+	parts := strings.Split(text, "\x00")
+	for i := 1; i < len(parts); i += 2 {
+		parts[i] = vars[parts[i]]
+	}
+	return strings.Join(parts, "")
+}
+
+func (s *stepStats) record(latency time.Duration, ok bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.calls++
+	if !ok {
+		s.errors++
+	}
+	s.latencies = append(s.latencies, latency)
+}
+
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+	index := int(float64(len(sorted)-1) * p)
+	return sorted[index]
+}
+
+func printReport(stats []stepStats, duration time.Duration) {
+	fmt.Printf("%-50s %8s %8s %10s %10s\n", "{{col.step}}", "{{col.requests}}", "{{col.errors}}", "P50", "P95")
+	total := 0
+	for i := range stats {
+		s := &stats[i]
+		sort.Slice(s.latencies, func(a, b int) bool { return s.latencies[a] < s.latencies[b] })
+		total += s.calls
+		fmt.Printf("%-50s %8d %8d %10s %10s\n", steps[i].Name, s.calls, s.errors,
+			percentile(s.latencies, 0.50).Round(time.Millisecond),
+			percentile(s.latencies, 0.95).Round(time.Millisecond))
+	}
+	fmt.Printf("\n{{codegen.total_line}}\n", total, float64(total)/duration.Seconds())
+}
+`
+
+// 生成压测脚本命令
+func runLoadScriptCommand(args []string) error {
+	analyzer := NewUniversalHARAnalyzer()
+
+	flags := flag.NewFlagSet("loadscript", flag.ExitOnError)
+	flags.StringVar(&analyzer.outputDir, "o", analyzer.outputDir, T("flag.output_dir"))
+	vus := flags.Int("vus", 10, T("flag.vus"))
+	duration := flags.Duration("duration", time.Minute, T("flag.duration"))
+	maxThink := flags.Duration("max-think", 30*time.Second, T("flag.max_think"))
+	filter := flags.String("filter", "", T("flag.filter"))
+	excludeStatic := flags.Bool("exclude-static", false, T("flag.exclude_static"))
+	configPath := addConfigFlag(flags)
+	addOutputNamingFlags(flags)
+	addLanguageFlag(flags)
+	flags.Parse(args)
+
+	if err := analyzer.Configure(flags, *configPath, *filter, *excludeStatic); err != nil {
+		return err
+	}
+
+	harFiles, err := analyzer.ResolveHARFiles(flags.Args())
+	if err != nil {
+		return err
+	}
+	if len(harFiles) == 0 {
+		fmt.Println("❌ " + T("common.no_har_files"))
+		return nil
+	}
+
+	analyzer.beginOutput(harFileRoots(flags.Args())...)
+	for _, filePath := range harFiles {
+		harFile, err := analyzer.LoadHARFile(filePath)
+		if err != nil {
+			fmt.Printf("❌ %s: %v\n", filepath.Base(filePath), err)
+			continue
+		}
+
+		baseName, err := analyzer.outputBaseName(filePath)
+		if err != nil {
+			return err
+		}
+		script := analyzer.BuildLoadScript(harFile, filepath.Base(filePath), *maxThink)
+
+		k6File := analyzer.artifactPath(baseName, "k6", ".js")
+		goDir := analyzer.artifactPath(baseName, "loadgen", "")
+		if err := os.MkdirAll(goDir, 0755); err != nil {
+			return fmt.Errorf("%s: %w", T("err.create_output_dir"), err)
+		}
+		if err := os.WriteFile(k6File, []byte(analyzer.GenerateK6Script(script, *vus, *duration)), 0644); err != nil {
+			return err
+		}
+		if err := analyzer.recordArtifact(k6File, "k6", filePath); err != nil {
+			return err
+		}
+		goFile := filepath.Join(goDir, "main.go")
+		if err := os.WriteFile(goFile, []byte(analyzer.GenerateGoLoadGenerator(script, *vus, *duration)), 0644); err != nil {
+			return err
+		}
+		if err := analyzer.recordArtifact(goFile, "loadgen", filePath); err != nil {
+			return err
+		}
+
+		fmt.Println("✅ " + T("loadscript.done", filepath.Base(filePath), len(script.Steps), len(script.Vars)))
+		fmt.Println("   " + T("loadscript.k6_file", k6File))
+		fmt.Println("   " + T("loadscript.go_file", goFile))
+	}
+
+	if err := analyzer.writeManifest(); err != nil {
+		fmt.Println("⚠️ " + T("output.manifest_failed", err))
+	}
+	return nil
+}
@@ -0,0 +1,101 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+)
+
+// 支持的输出语言
+var supportedLanguages = []string{"zh", "en"}
+
+// 各语言的消息目录
+var messageCatalogs = map[string]map[string]string{
+	"zh": zhMessages,
+	"en": enMessages,
+}
+
+// 当前输出语言（未设置 -lang 且无法从环境变量判断时使用中文）
+var currentLanguage = "zh"
+
+// 生成代码模板中的消息占位符，如 {{flag.vus}}
+var codeTemplateMessagePattern = regexp.MustCompile(`\{\{([a-z0-9_.]+)\}\}`)
+
+// 获取当前语言的消息，有参数时按格式化字符串处理
+func T(key string, args ...interface{}) string {
+	message, ok := messageCatalogs[currentLanguage][key]
+	if !ok {
+		message, ok = zhMessages[key]
+	}
+	if !ok {
+		message = key
+	}
+	if len(args) == 0 {
+		return message
+	}
+	return fmt.Sprintf(message, args...)
+}
+
+// 设置输出语言
+func SetLanguage(lang string) error {
+	lang = strings.ToLower(strings.TrimSpace(lang))
+	if _, ok := messageCatalogs[lang]; !ok {
+		return fmt.Errorf("%s", T("err.unsupported_language", lang, strings.Join(supportedLanguages, ", ")))
+	}
+	currentLanguage = lang
+	return nil
+}
+
+// 根据 LC_ALL、LC_MESSAGES、LANG 环境变量判断输出语言
+func detectLanguage() string {
+	for _, name := range []string{"LC_ALL", "LC_MESSAGES", "LANG"} {
+		value := strings.ToLower(os.Getenv(name))
+		if value == "" {
+			continue
+		}
+		if strings.HasPrefix(value, "zh") {
+			return "zh"
+		}
+		if value == "c" || value == "posix" || strings.HasPrefix(value, "c.") {
+			continue
+		}
+		return "en"
+	}
+	return "zh"
+}
+
+// 初始化输出语言（在定义命令行参数之前调用，使参数说明也能本地化）
+func initLanguage(args []string) error {
+	currentLanguage = detectLanguage()
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if arg == "--" {
+			break
+		}
+		name := strings.TrimLeft(arg, "-")
+		if name == arg || len(arg)-len(name) > 2 {
+			continue
+		}
+		if name == "lang" && i+1 < len(args) {
+			return SetLanguage(args[i+1])
+		}
+		if strings.HasPrefix(name, "lang=") {
+			return SetLanguage(strings.TrimPrefix(name, "lang="))
+		}
+	}
+	return nil
+}
+
+// 注册 -lang 参数（实际取值已由 initLanguage 处理）
+func addLanguageFlag(flags *flag.FlagSet) {
+	flags.String("lang", currentLanguage, T("flag.lang", strings.Join(supportedLanguages, ", ")))
+}
+
+// 替换生成代码模板中的消息占位符
+func localizeCodeTemplate(code string) string {
+	return codeTemplateMessagePattern.ReplaceAllStringFunc(code, func(match string) string {
+		return T(codeTemplateMessagePattern.FindStringSubmatch(match)[1])
+	})
+}
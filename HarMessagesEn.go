@@ -0,0 +1,435 @@
+package main
+
+// 英文消息目录
+var enMessages = map[string]string{
+	// 通用
+	"common.no_har_files": "No HAR files found",
+	"common.save_failed":  "Failed to save results: %v",
+	"common.view_results": "Results: %s",
+
+	// 错误信息
+	"err.scan_har":             "failed to scan HAR files",
+	"err.read_har":             "failed to read HAR file",
+	"err.parse_har":            "failed to parse HAR file",
+	"err.create_output_dir":    "failed to create output directory",
+	"err.save_result":          "failed to save results",
+	"err.unsupported_export":   "unsupported export format: %s (available: %s)",
+	"err.unknown_column":       "unknown export column: %s (available: %s, req:<header>, res:<header>)",
+	"err.remove_database":      "failed to remove old database",
+	"err.write_export":         "failed to write %s",
+	"err.open_database":        "failed to open database",
+	"err.create_tables":        "failed to create tables",
+	"err.unsupported_language": "unsupported language: %s (available: %s)",
+
+	// 分析流程
+	"analyze.start":       "Universal HAR analyzer started",
+	"analyze.file":        "Analyzing HAR file: %s",
+	"analyze.found_files": "Found %d HAR files",
+	"analyze.failed":      "Analysis failed: %v",
+	"analyze.file_done":   "Analyzed: %d requests, %d hosts, %d APIs",
+	"analyze.all_done":    "Analysis complete! Results saved to: %s",
+	"analyze.done":        "Analysis complete!",
+	"analyze.time_span":   "%s - %s (%.1f min)",
+
+	// 命令行参数
+	"flag.output_dir":     "output directory",
+	"flag.filter":         `entry filter expression, e.g. 'host ~ "api." && status >= 400'`,
+	"flag.exclude_static": "exclude static resources such as images, CSS, JS and fonts",
+	"flag.export":         "per-entry export formats, comma separated: csv, ndjson, sqlite",
+	"flag.columns":        "CSV/NDJSON export columns, comma separated; supports req:<header> and res:<header>",
+	"flag.lang":           "output language: %s (defaults to the LANG environment variable)",
+	"flag.target":         "target base URL, e.g. http://localhost:8080 (required)",
+	"flag.vus":            "number of virtual users",
+	"flag.duration":       "load test duration",
+	"flag.scale":          "think time multiplier (0 disables waiting)",
+	"flag.max_think":      "maximum think time per step (0 means unlimited)",
+	"flag.timeout":        "per-request timeout",
+	"flag.db":             "SQLite database file (HAR files are loaded into an in-memory database when empty)",
+	"flag.query_format":   "output format: table, csv",
+	"flag.config":         "configuration file (defaults to %s in the current directory)",
+	"flag.template":       "report template: markdown, html or a template file path",
+	"flag.schema":         "print the table schema",
+	"flag.naming":         "output file naming: %s (path uses the relative path, hash uses the file name plus a content hash)",
+	"flag.proto":          "comma-separated protobuf descriptor set files (protoc --descriptor_set_out --include_imports) used to decode gRPC messages",
+	"flag.output_mode":    "output write mode: %s (overwrite, append a timestamp, or append an incrementing version)",
+	"flag.cache_dir":      "analysis cache directory (default %s inside the output directory)",
+	"flag.no_cache":       "ignore cached analysis results and re-analyze every file (the cache is still refreshed)",
+	"flag.watch_interval": "polling interval",
+	"flag.watch_settle":   "treat a file as fully written once its modification time is this old and its size stops changing",
+	"flag.watch_debounce": "wait this long after the last change before updating the summary report",
+	"flag.watch_gate":     "command to run after the summary report is updated (a non-zero exit code fails the gate)",
+	"flag.ui_addr":        "listen address",
+
+	// 分析报告
+	"report.title":          "HAR Analysis Report: %s",
+	"report.analysis_time":  "Analyzed at",
+	"report.basic_info":     "Overview",
+	"report.total_requests": "Total requests",
+	"report.unique_hosts":   "Unique hosts",
+	"report.time_span":      "Time span",
+	"report.browser":        "Browser",
+	"report.har_version":    "HAR version",
+	"report.filter":         "Filter",
+	"report.filtered_out":   "%d requests excluded",
+	"report.hosts":          "Hosts",
+	"report.top_apis":       "Top APIs (calls > %d)",
+	"report.top_params":     "Common Parameters (occurrences > %d)",
+	"report.request_bodies": "Request Bodies",
+	"report.top_headers":    "Common Request Headers (occurrences > %d)",
+	"report.methods":        "HTTP Methods",
+	"report.status_codes":   "Status Codes",
+	"report.response_types": "Response Types",
+	"report.code_templates": "Code Templates",
+	"report.go_structs":     "Go Structs",
+	"report.header_setup":   "Common Header Setup",
+	"report.api_endpoints":  "API Endpoints",
+	"report.no_data":        "No data",
+	"report.total":          "Total",
+	"report.item_count":     "%d items",
+
+	// GraphQL报告
+	"report.graphql_overview":               "%d GraphQL requests (%d batched), %d operations",
+	"report.graphql_batched":                "%d batched",
+	"report.graphql_errors":                 "GraphQL Errors",
+	"report.graphql_schema":                 "Inferred Schema (partial SDL)",
+	"report.graphql_go_types":               "Go Types for Variables and Responses",
+	"report.jsonrpc_overview":               "%d JSON-RPC requests (%d batched), %d notifications, %d methods",
+	"report.jsonrpc_errors":                 "JSON-RPC Errors",
+	"report.jsonrpc_go_client":              "Go Client",
+	"report.grpc_overview":                  "%d gRPC-Web/protobuf requests, %d methods",
+	"report.grpc_descriptors":               "decoded with the supplied descriptor sets where the method was found",
+	"report.grpc_statuses":                  "gRPC Status",
+	"report.grpc_examples":                  "Example Messages",
+	"report.grpc_compressed":                "compressed, not decoded",
+	"report.grpc_request":                   "Request",
+	"report.grpc_response":                  "Response",
+	"report.redirects":                      "Redirect Chains",
+	"report.redirects_overview":             "%d redirects in %d chains",
+	"report.redirects_unnecessary":          "Unnecessary Redirects",
+	"report.redirects_unnecessary_overview": "%d unnecessary redirects cost %s",
+	"report.redirect_downgrade":             "HTTPS→HTTP downgrade ×%d",
+	"report.redirect_cross_host":            "cross-host ×%d",
+	"report.redirect_loop":                  "loop",
+	"redirect.trailing_slash":               "Trailing slash only",
+	"redirect.https_upgrade":                "HTTP→HTTPS upgrade (avoidable with HSTS)",
+	"report.caching":                        "Caching Audit",
+	"report.caching_overview":               "%d responses, %d served from the browser cache; %d conditional requests, %d returned 304 (%s)",
+	"report.caching_savings":                "Estimated savings with proper caching: %s transferred and %s of request time",
+	"report.caching_hosts":                  "By Host",
+	"report.caching_repeated":               "Repeated Downloads of the Same URL",
+	"report.caching_duplicates":             "Identical Content at Different URLs",
+	"report.caching_duplicates_note":        "For reference only: the browser cache is keyed by URL, so these are not included in the savings estimate",
+	"report.caching_missing_validators":     "Static Assets Without Validators (ETag / Last-Modified)",
+	"cache.fresh":                           "Fresh (max-age / Expires)",
+	"cache.heuristic":                       "Heuristic (Last-Modified only)",
+	"cache.revalidate":                      "Revalidated on every use",
+	"cache.no_store":                        "Not stored (no-store)",
+	"cache.not_cacheable":                   "Not cacheable (method / status)",
+	"cache.unspecified":                     "No caching headers",
+	"report.compression":                    "Compression Audit",
+	"report.compression_overview":           "%d responses with a body, %d compressed; %s of content transferred as %s",
+	"report.compression_savings":            "%d compressible responses were sent uncompressed; compressing them would save %s",
+	"report.compression_hosts":              "Savings by Host",
+	"report.compression_types":              "Savings by Content Type",
+	"report.compression_uncompressed":       "Compressible Responses Sent Uncompressed",
+	"report.pages":                          "Page Load Performance",
+	"report.pages_within_budget":            "✅ within budget",
+	"report.pages_critical":                 "%d requests (%s) finished before onLoad; the last ones to finish:",
+	"report.pages_render_blocking":          "Render-Blocking CSS / JS",
+	"report.pages_largest":                  "Largest Resources",
+	"report.pages_slowest":                  "Slowest Resources",
+	"page.budget_requests":                  "%d requests (budget %d)",
+	"page.budget_bytes":                     "%s transferred (budget %s)",
+	"page.budget_dom_content_loaded":        "DOMContentLoaded %s (budget %s)",
+	"page.budget_onload":                    "onLoad %s (budget %s)",
+	"page.budget_render_blocking":           "%d render-blocking requests (budget %d)",
+	"report.websocket_overview":             "%d WebSocket connections, %d messages sent, %d received",
+	"report.websocket_types":                "Message Types",
+	"report.websocket_go_types":             "Go Types for Message Payloads",
+	"report.streams":                        "Streaming Responses (SSE / NDJSON)",
+	"report.streams_overview":               "%d streaming responses, %d events",
+	"report.streams_types":                  "Event Types",
+	"report.streams_go_types":               "Go Types for Event Payloads",
+
+	// 汇总报告
+	"summary.title":              "Universal HAR Analysis Summary",
+	"summary.generated_at":       "Generated at",
+	"summary.file_count":         "Files analyzed",
+	"summary.files":              "Analyzed Files",
+	"summary.usage":              "How to Use",
+	"summary.usage_1":            "Each HAR file has a matching JSON analysis result and Markdown report",
+	"summary.usage_2":            "JSON files contain the full structured data for further processing",
+	"summary.usage_3":            "Markdown reports present the results in a human-readable form",
+	"summary.usage_4":            "Code templates can be copied and used directly",
+	"summary.generated_files":    "Generated Files",
+	"summary.file_analysis_json": "structured analysis data",
+	"summary.file_report_md":     "human-readable analysis report",
+	"summary.file_summary_md":    "this summary report",
+	"summary.file_summary_json":  "structured summary data",
+	"summary.file_manifest":      "every generated file with its SHA-256 checksum",
+	"summary.overall":            "Overall",
+	"summary.unique_endpoints":   "Unique endpoints",
+	"summary.global_hosts":       "Hosts Across Files",
+	"summary.global_endpoints":   "Endpoints Across Files",
+	"summary.partial_endpoints":  "Endpoints Seen Only in Some Files",
+	"summary.failed":             "Failed to generate summary report: %v",
+
+	// 表格列名
+	"col.host":            "Host",
+	"col.requests":        "Requests",
+	"col.http_methods":    "HTTP Methods",
+	"col.method":          "Method",
+	"col.path":            "Path",
+	"col.calls":           "Calls",
+	"col.response_type":   "Response Type",
+	"col.param":           "Parameter",
+	"col.occurrences":     "Occurrences",
+	"col.header":          "Header",
+	"col.uses":            "Uses",
+	"col.status":          "Status",
+	"col.expected_status": "Expected",
+	"col.type":            "Type",
+	"col.min":             "Min",
+	"col.mean":            "Mean",
+	"col.max":             "Max",
+	"col.errors":          "Errors",
+	"col.error_rate":      "Error Rate",
+	"col.throughput":      "Throughput (req/s)",
+	"col.file":            "File",
+	"col.hosts":           "Hosts",
+	"col.apis":            "APIs",
+	"col.seen_in":         "Seen In",
+	"col.missing_in":      "Missing In",
+	"col.step":            "Step",
+	"col.time":            "Time",
+	"col.size":            "Size",
+	"col.operation":       "Operation",
+	"col.endpoint":        "Endpoint",
+	"col.variables":       "Variables",
+	"col.url":             "URL",
+	"col.duration":        "Duration",
+	"col.sent":            "Sent",
+	"col.received":        "Received",
+	"col.bytes_sent":      "Bytes Sent",
+	"col.bytes_received":  "Bytes Received",
+	"col.rate":            "Rate (/s)",
+	"col.max_size":        "Max Size",
+	"col.message_types":   "Message Types",
+	"col.format":          "Format",
+	"col.fields":          "Fields",
+	"col.bytes":           "Bytes",
+	"col.go_type":         "Go Type",
+	"col.events":          "Events",
+	"col.first_byte":      "First Byte",
+	"col.event_types":     "Event Types",
+	"col.count":           "Count",
+	"col.params":          "Params",
+	"col.service_method":  "Service/Method",
+	"col.protocol":        "Protocol",
+	"col.request_bytes":   "Request Bytes",
+	"col.response_bytes":  "Response Bytes",
+	"col.messages":        "Messages",
+	"col.start":           "Start",
+	"col.final":           "Final",
+	"col.redirects":       "Redirects",
+	"col.notes":           "Notes",
+	"col.from":            "From",
+	"col.to":              "To",
+	"col.reason":          "Reason",
+	"col.cacheability":    "Cacheability",
+	"col.cacheable":       "Cacheable",
+	"col.from_cache":      "From Cache",
+	"col.revalidated":     "304 / Conditional",
+	"col.downloads":       "Downloads",
+	"col.urls":            "URLs",
+	"col.duplicate_bytes": "Duplicate Bytes",
+	"col.wasted_bytes":    "Wasted Bytes",
+	"col.wasted_time":     "Wasted Time",
+	"col.original":        "Original",
+	"col.transferred":     "Transferred",
+	"col.ratio":           "Ratio",
+	"col.uncompressed":    "Uncompressed",
+	"col.savable":         "Savable",
+	"col.page":            "Page",
+	"col.render_blocking": "Render-Blocking",
+	"col.budget":          "Budget",
+	"col.started":         "Started",
+	"col.finished":        "Finished",
+
+	// 生成的代码
+	"codegen.api_endpoint":          "%s %s (%d calls)",
+	"codegen.header_count":          "seen %d times",
+	"codegen.generated_from":        "Generated by UniversalHarAnalyzer from %s",
+	"codegen.run_hint":              "Run: %s",
+	"codegen.extract_comment":       "Extracts a correlated value from the JSON response by path",
+	"codegen.expand_comment":        "Replaces placeholders with correlated variable values",
+	"codegen.run_iteration_comment": "Runs one complete user journey",
+	"codegen.total_line":            "Total requests: %d, throughput: %.2f req/s",
+	"codegen.rpc_method":            "calls %s on %s (seen %d times)",
+
+	// 明细导出
+	"export.failed":      "Failed to export entries: %v",
+	"export.sqlite_file": "SQLite database: %s",
+
+	// 过滤表达式
+	"filter.empty":               "filter expression is empty",
+	"filter.syntax_error":        "filter syntax error near character %d: %s",
+	"filter.trailing":            "unexpected %q",
+	"filter.unterminated_string": "unterminated string",
+	"filter.unknown_operator":    "unknown operator %q",
+	"filter.unexpected_char":     "unexpected character %q",
+	"filter.incomplete":          "incomplete expression",
+	"filter.unclosed_paren":      "unclosed parenthesis",
+	"filter.expected_field":      "expected a field name, got %q",
+	"filter.unknown_field":       "unknown field %q",
+	"filter.missing_operator":    "missing comparison operator after field %q",
+	"filter.missing_value":       "missing value after operator %q",
+	"filter.numeric_operator":    "numeric field %q does not support operator %q",
+	"filter.expected_number":     "field %q expects a number, got %q",
+	"filter.expected_string":     "field %q expects a string, got %q",
+	"filter.invalid_regex":       "invalid regular expression: %v",
+	"filter.invalid_number":      "invalid number %q",
+	"filter.invalid_unit":        "field %q does not support unit %q",
+
+	// 压测脚本生成
+	"loadscript.failed":  "Failed to generate load scripts: %v",
+	"loadscript.done":    "%s: %d steps, %d correlated variables",
+	"loadscript.k6_file": "k6 script: %s",
+	"loadscript.go_file": "Go load generator: %s",
+
+	// 压测
+	"load.failed":          "Load test failed: %v",
+	"load.target_required": "a target must be specified with -target",
+	"load.invalid_target":  "invalid target URL: %s",
+	"load.invalid_vus":     "number of virtual users must be greater than 0",
+	"load.invalid_scale":   "think time multiplier must not be negative",
+	"load.har_required":    "exactly one HAR file is required",
+	"load.no_requests":     "the HAR file contains no replayable requests",
+	"load.multiple_hosts":  "requests to %s are all sent to %s; use -filter 'host == \"...\"' to replay only the application's own host",
+	"load.start":           "Starting load test: %s -> %s (%d virtual users, %s)",
+	"load.done":            "Load test finished: %d requests, %d errors, %.2f req/s, P95 %.1fms",
+	"load.interrupted":     "Load test interrupted after %s, saving partial results",
+	"load.partial":         "The run was interrupted before the configured duration; results are partial",
+	"load.report_title":    "HAR Load Test Report: %s",
+	"load.start_time":      "Started at",
+	"load.target":          "Target",
+	"load.vus":             "Virtual users",
+	"load.duration":        "Duration",
+	"load.think_scale":     "Think time multiplier",
+	"load.iterations":      "Completed iterations",
+	"load.errors":          "Errors",
+	"load.throughput":      "Throughput",
+	"load.rps":             "%.2f req/s",
+	"load.latency":         "Latency (ms)",
+	"load.endpoints":       "Endpoints",
+
+	// 配置文件
+	"config.read_failed":             "failed to read configuration file",
+	"config.parse_failed":            "failed to parse configuration file %s",
+	"config.unknown_field":           "unknown field %s",
+	"config.unsupported_format":      "unsupported configuration file format: %s (available: .yaml, .yml, .toml)",
+	"config.invalid":                 "invalid configuration file %s",
+	"config.empty_value":             "%s must not contain empty values",
+	"config.negative_threshold":      "thresholds.%s must not be negative",
+	"config.invalid_max_items":       "maxItems must be greater than 0",
+	"config.invalid_content_type":    "contentTypes[%d] requires both type and match",
+	"config.invalid_redaction":       "redact[%d] must specify exactly one of header, param, cookie, value",
+	"config.invalid_redaction_regex": "redact[%d] has an invalid regular expression: %v",
+	"config.invalid_page_budget":     "pageBudgets[%d] must not have negative limits",
+	"config.unknown_section":         "unknown section %q in sections (available: %s)",
+	"config.invalid_watch_interval":  "watch.interval must be greater than 0",
+	"config.negative_duration":       "%s must not be negative",
+	"grpc.descriptor_read_failed":    "failed to read protobuf descriptor set %s",
+	"grpc.descriptor_invalid":        "invalid protobuf descriptor set %s",
+
+	// 报告模板
+	"template.read_failed":       "failed to read report template %s",
+	"template.parse_failed":      "failed to parse report template %s",
+	"template.render_failed":     "failed to render report template %s",
+	"template.unknown_threshold": "unknown threshold %q",
+	"template.unknown_field":     "unknown field %q",
+	"template.not_a_list":        "%s requires a list argument",
+	"template.not_a_map":         "%s requires a map argument",
+	"template.invalid_dict":      "dict arguments must be pairs of string keys and values",
+
+	// 输出文件
+	"output.unsupported_naming": "unsupported naming mode: %s (available: %s)",
+	"output.unsupported_mode":   "unsupported output mode: %s (available: %s)",
+	"output.manifest_failed":    "failed to write the output manifest: %v",
+
+	// 分析结果缓存
+	"cache.hit":          "File unchanged, using the cached analysis result",
+	"cache.store_failed": "Failed to write the analysis cache: %v",
+	"cache.summary":      "Re-analyzed %d files, %d files served from the cache",
+
+	// 目录监视
+	"watch.failed":          "Watch failed: %v",
+	"watch.usage":           "Usage: %s watch [options] [directory...]",
+	"watch.not_directory":   "%s is not a directory",
+	"watch.start":           "Watching %s (polling every %s, press Ctrl+C to stop)",
+	"watch.scan_failed":     "Failed to scan %s: %v",
+	"watch.removed":         "File deleted, removed from the summary report: %s",
+	"watch.summary_updated": "Summary report updated (%d files)",
+	"watch.gate_running":    "Running gate command: %s",
+	"watch.gate_passed":     "Gate command passed",
+	"watch.gate_failed":     "Gate command failed: %v",
+	"watch.stopping":        "Stopping watch...",
+	"watch.stopped":         "Watch stopped",
+
+	// Web界面
+	"ui.failed":         "Failed to start the web UI: %v",
+	"ui.usage":          "Usage: %s ui [options] [HAR directory]",
+	"ui.listening":      "Web UI running at %s (HAR directory: %s, press Ctrl+C to stop)",
+	"ui.file_not_found": "file not found: %s",
+	"ui.forbidden_host": "host not allowed: %s (open the UI via %s, localhost or 127.0.0.1)",
+
+	// Web界面文本
+	"web.title":            "Universal HAR Analyzer",
+	"web.files":            "HAR files",
+	"web.upload":           "Upload HAR",
+	"web.uploading":        "Uploading...",
+	"web.uploaded":         "uploaded",
+	"web.no_files":         "No HAR files in the directory",
+	"web.select_file":      "Pick or upload a HAR file on the left",
+	"web.loading":          "Loading...",
+	"web.error":            "Error: %s",
+	"web.filter":           "Filter...",
+	"web.apis":             "APIs",
+	"web.parameters":       "Parameters",
+	"web.headers":          "Headers",
+	"web.downloads":        "Download",
+	"web.calls":            "Calls: %s %s",
+	"web.time":             "Time",
+	"web.size":             "Size",
+	"web.value":            "Value",
+	"web.request_headers":  "Request headers",
+	"web.request_body":     "Request body",
+	"web.response_headers": "Response headers",
+	"web.response_body":    "Response body",
+	"web.empty":            "(empty)",
+	"web.compare":          "Compare files",
+	"web.compare_run":      "Compare",
+	"web.compare_title":    "Comparison: %s ↔ %s",
+
+	// 终端界面
+	"tui.failed":            "Terminal UI failed: %v",
+	"tui.usage":             "Usage: %s tui [options] <HAR file or directory>...",
+	"tui.title":             " Universal HAR Analyzer · %s · %d/%d entries",
+	"tui.api_filter":        "[API: %s %s]",
+	"tui.no_entries":        "No matching entries",
+	"tui.help_entries":      "↑↓/jk move  Tab details  / filter  s sort  r reverse  a APIs  Esc clear  q quit",
+	"tui.help_apis":         "↑↓/jk move  Enter show calls  Esc back  q quit",
+	"tui.filter_editing":    "Filter: %s▏ (Enter to apply, Esc to clear)",
+	"tui.filter_status":     "Filter (%s): %s",
+	"tui.filter_text":       "text",
+	"tui.filter_expression": "expression",
+	"tui.sort_status":       "Sort: %s%s",
+
+	// SQL查询
+	"query.failed":             "Query failed: %v",
+	"query.usage":              `Usage: %s query [options] "SQL" [HAR files or directories...]`,
+	"query.missing_sql":        "missing SQL statement",
+	"query.unsupported_format": "unsupported output format: %s",
+	"query.import_failed":      "failed to import %s",
+	"query.row_count":          "(%d rows)",
+}
@@ -0,0 +1,435 @@
+package main
+
+// 中文消息目录
+var zhMessages = map[string]string{
+	// 通用
+	"common.no_har_files": "未找到HAR文件",
+	"common.save_failed":  "保存结果失败: %v",
+	"common.view_results": "查看结果: %s",
+
+	// 错误信息
+	"err.scan_har":             "扫描HAR文件失败",
+	"err.read_har":             "读取HAR文件失败",
+	"err.parse_har":            "解析HAR文件失败",
+	"err.create_output_dir":    "创建输出目录失败",
+	"err.save_result":          "保存结果失败",
+	"err.unsupported_export":   "不支持的导出格式: %s（可选: %s）",
+	"err.unknown_column":       "未知的导出列: %s（可选: %s, req:<请求头>, res:<响应头>）",
+	"err.remove_database":      "删除旧数据库失败",
+	"err.write_export":         "写入%s失败",
+	"err.open_database":        "打开数据库失败",
+	"err.create_tables":        "创建数据表失败",
+	"err.unsupported_language": "不支持的语言: %s（可选: %s）",
+
+	// 分析流程
+	"analyze.start":       "通用HAR分析器启动",
+	"analyze.file":        "分析HAR文件: %s",
+	"analyze.found_files": "发现 %d 个HAR文件",
+	"analyze.failed":      "分析失败: %v",
+	"analyze.file_done":   "分析完成: %d个请求, %d个主机, %d个API",
+	"analyze.all_done":    "分析完成! 结果保存在: %s",
+	"analyze.done":        "分析完成!",
+	"analyze.time_span":   "%s - %s (%.1f分钟)",
+
+	// 命令行参数
+	"flag.output_dir":     "输出目录",
+	"flag.filter":         `请求过滤表达式，如 'host ~ "api." && status >= 400'`,
+	"flag.exclude_static": "排除图片、CSS、JS、字体等静态资源",
+	"flag.export":         "明细数据导出格式，多个用逗号分隔: csv, ndjson, sqlite",
+	"flag.columns":        "CSV/NDJSON导出列，逗号分隔，支持 req:<请求头> 和 res:<响应头>",
+	"flag.lang":           "输出语言: %s（默认根据 LANG 环境变量选择）",
+	"flag.target":         "目标基础地址，如 http://localhost:8080（必填）",
+	"flag.vus":            "虚拟用户数",
+	"flag.duration":       "压测持续时间",
+	"flag.scale":          "思考时间倍率（0表示不等待）",
+	"flag.max_think":      "单次思考时间上限（0表示不限制）",
+	"flag.timeout":        "单个请求超时时间",
+	"flag.db":             "SQLite数据库文件（为空时将HAR加载到内存数据库）",
+	"flag.query_format":   "输出格式: table, csv",
+	"flag.config":         "配置文件（默认查找当前目录下的 %s）",
+	"flag.template":       "报告模板: markdown、html 或模板文件路径",
+	"flag.schema":         "输出数据表结构",
+	"flag.naming":         "输出文件命名方式: %s（path 按相对路径，hash 按文件名加内容哈希）",
+	"flag.proto":          "用于解码gRPC消息的protobuf描述符集合文件，逗号分隔（protoc --descriptor_set_out --include_imports 生成）",
+	"flag.output_mode":    "输出文件写入模式: %s（覆盖、追加时间戳、递增版本号）",
+	"flag.cache_dir":      "分析结果缓存目录（默认为输出目录下的 %s）",
+	"flag.no_cache":       "忽略已有的分析结果缓存，重新分析所有文件（仍会更新缓存）",
+	"flag.watch_interval": "轮询间隔",
+	"flag.watch_settle":   "文件修改时间超过该时长且大小不再变化时视为写入完成",
+	"flag.watch_debounce": "最后一次变化后等待该时长再更新汇总报告",
+	"flag.watch_gate":     "汇总报告更新后执行的检查命令（非0退出码视为未通过）",
+	"flag.ui_addr":        "监听地址",
+
+	// 分析报告
+	"report.title":          "HAR分析报告: %s",
+	"report.analysis_time":  "分析时间",
+	"report.basic_info":     "基本信息",
+	"report.total_requests": "总请求数",
+	"report.unique_hosts":   "唯一主机数",
+	"report.time_span":      "时间跨度",
+	"report.browser":        "浏览器",
+	"report.har_version":    "HAR版本",
+	"report.filter":         "过滤条件",
+	"report.filtered_out":   "已排除%d个请求",
+	"report.hosts":          "主机统计",
+	"report.top_apis":       "热门API (调用次数 > %d)",
+	"report.top_params":     "常用参数 (出现次数 > %d)",
+	"report.request_bodies": "请求体",
+	"report.top_headers":    "常用请求头 (出现次数 > %d)",
+	"report.methods":        "HTTP方法统计",
+	"report.status_codes":   "状态码统计",
+	"report.response_types": "响应类型统计",
+	"report.code_templates": "代码模板",
+	"report.go_structs":     "Go结构体",
+	"report.header_setup":   "常用请求头设置",
+	"report.api_endpoints":  "API端点列表",
+	"report.no_data":        "无数据",
+	"report.total":          "总计",
+	"report.item_count":     "%d项",
+
+	// GraphQL报告
+	"report.graphql_overview":               "共 %d 个GraphQL请求（其中 %d 个批量请求），%d 个操作",
+	"report.graphql_batched":                "批量 %d",
+	"report.graphql_errors":                 "GraphQL错误",
+	"report.graphql_schema":                 "推断的Schema（部分SDL）",
+	"report.graphql_go_types":               "变量和响应的Go类型",
+	"report.jsonrpc_overview":               "%d 个JSON-RPC请求（%d 个批量请求），%d 个通知，%d 个方法",
+	"report.jsonrpc_errors":                 "JSON-RPC错误",
+	"report.jsonrpc_go_client":              "Go客户端",
+	"report.grpc_overview":                  "%d 个gRPC-Web/protobuf请求，%d 个方法",
+	"report.grpc_descriptors":               "能在描述符集合中找到的方法按描述符解码",
+	"report.grpc_statuses":                  "gRPC状态",
+	"report.grpc_examples":                  "示例消息",
+	"report.grpc_compressed":                "消息已压缩，未解码",
+	"report.grpc_request":                   "请求",
+	"report.grpc_response":                  "响应",
+	"report.redirects":                      "重定向链",
+	"report.redirects_overview":             "%d 次重定向，共 %d 条链",
+	"report.redirects_unnecessary":          "不必要的重定向",
+	"report.redirects_unnecessary_overview": "%d 次不必要的重定向，耗时 %s",
+	"report.redirect_downgrade":             "HTTPS→HTTP降级 ×%d",
+	"report.redirect_cross_host":            "跨主机 ×%d",
+	"report.redirect_loop":                  "循环",
+	"redirect.trailing_slash":               "只差末尾斜杠",
+	"redirect.https_upgrade":                "HTTP→HTTPS跳转（可用HSTS避免）",
+	"report.caching":                        "缓存审计",
+	"report.caching_overview":               "%d 个响应，%d 个直接读取浏览器缓存；%d 个条件请求，%d 个返回304（%s）",
+	"report.caching_savings":                "合理缓存后预计可节省 %s 传输和 %s 请求耗时",
+	"report.caching_hosts":                  "按主机统计",
+	"report.caching_repeated":               "同一地址的重复下载",
+	"report.caching_duplicates":             "不同地址的相同内容",
+	"report.caching_duplicates_note":        "仅供参考：浏览器缓存按地址存储，这些下载不计入节省估算",
+	"report.caching_missing_validators":     "缺少验证器（ETag / Last-Modified）的静态资源",
+	"cache.fresh":                           "有新鲜期（max-age / Expires）",
+	"cache.heuristic":                       "启发式缓存（只有 Last-Modified）",
+	"cache.revalidate":                      "每次使用前再验证",
+	"cache.no_store":                        "禁止缓存（no-store）",
+	"cache.not_cacheable":                   "不可缓存（方法或状态码）",
+	"cache.unspecified":                     "没有缓存响应头",
+	"report.compression":                    "压缩审计",
+	"report.compression_overview":           "%d 个有响应体的响应，%d 个已压缩；%s 内容实际传输 %s",
+	"report.compression_savings":            "%d 个可压缩的响应未压缩，压缩后可节省 %s",
+	"report.compression_hosts":              "按主机统计可节省的字节",
+	"report.compression_types":              "按内容类型统计可节省的字节",
+	"report.compression_uncompressed":       "未压缩的可压缩响应",
+	"report.pages":                          "页面加载性能",
+	"report.pages_within_budget":            "✅ 符合预算",
+	"report.pages_critical":                 "onLoad 之前完成了 %d 个请求（%s），最后完成的请求：",
+	"report.pages_render_blocking":          "阻塞渲染的CSS / JS",
+	"report.pages_largest":                  "最大的资源",
+	"report.pages_slowest":                  "最慢的资源",
+	"page.budget_requests":                  "%d 个请求（预算 %d）",
+	"page.budget_bytes":                     "传输 %s（预算 %s）",
+	"page.budget_dom_content_loaded":        "DOMContentLoaded %s（预算 %s）",
+	"page.budget_onload":                    "onLoad %s（预算 %s）",
+	"page.budget_render_blocking":           "%d 个阻塞渲染的请求（预算 %d）",
+	"report.websocket_overview":             "%d 个WebSocket连接，发送 %d 条消息，接收 %d 条",
+	"report.websocket_types":                "消息类型",
+	"report.websocket_go_types":             "消息负载的Go类型",
+	"report.streams":                        "流式响应（SSE / NDJSON）",
+	"report.streams_overview":               "%d 个流式响应，共 %d 个事件",
+	"report.streams_types":                  "事件类型",
+	"report.streams_go_types":               "事件负载的Go类型",
+
+	// 汇总报告
+	"summary.title":              "通用HAR分析汇总报告",
+	"summary.generated_at":       "生成时间",
+	"summary.file_count":         "分析文件数",
+	"summary.files":              "分析的文件列表",
+	"summary.usage":              "使用说明",
+	"summary.usage_1":            "每个HAR文件都生成了对应的JSON分析结果和Markdown报告",
+	"summary.usage_2":            "JSON文件包含完整的结构化数据，可用于程序处理",
+	"summary.usage_3":            "Markdown报告提供人类可读的分析结果",
+	"summary.usage_4":            "代码模板可以直接复制使用",
+	"summary.generated_files":    "生成的文件说明",
+	"summary.file_analysis_json": "结构化分析数据",
+	"summary.file_report_md":     "可读性分析报告",
+	"summary.file_summary_md":    "本汇总报告",
+	"summary.file_summary_json":  "结构化汇总数据",
+	"summary.file_manifest":      "本次生成的所有文件及其SHA-256校验和",
+	"summary.overall":            "整体统计",
+	"summary.unique_endpoints":   "唯一端点数",
+	"summary.global_hosts":       "全局主机统计",
+	"summary.global_endpoints":   "全局端点统计",
+	"summary.partial_endpoints":  "仅在部分文件中出现的端点",
+	"summary.failed":             "生成汇总报告失败: %v",
+
+	// 表格列名
+	"col.host":            "主机",
+	"col.requests":        "请求数",
+	"col.http_methods":    "HTTP方法",
+	"col.method":          "方法",
+	"col.path":            "路径",
+	"col.calls":           "调用次数",
+	"col.response_type":   "响应类型",
+	"col.param":           "参数名",
+	"col.occurrences":     "出现次数",
+	"col.header":          "请求头",
+	"col.uses":            "使用次数",
+	"col.status":          "状态码",
+	"col.expected_status": "预期状态码",
+	"col.type":            "类型",
+	"col.min":             "最小",
+	"col.mean":            "平均",
+	"col.max":             "最大",
+	"col.errors":          "错误数",
+	"col.error_rate":      "错误率",
+	"col.throughput":      "吞吐量(请求/秒)",
+	"col.file":            "文件",
+	"col.hosts":           "主机数",
+	"col.apis":            "API数",
+	"col.seen_in":         "出现于",
+	"col.missing_in":      "缺失于",
+	"col.step":            "步骤",
+	"col.time":            "耗时",
+	"col.size":            "大小",
+	"col.operation":       "操作",
+	"col.endpoint":        "端点",
+	"col.variables":       "变量",
+	"col.url":             "URL",
+	"col.duration":        "时长",
+	"col.sent":            "发送",
+	"col.received":        "接收",
+	"col.bytes_sent":      "发送字节",
+	"col.bytes_received":  "接收字节",
+	"col.rate":            "速率（/秒）",
+	"col.max_size":        "最大消息",
+	"col.message_types":   "消息类型",
+	"col.format":          "格式",
+	"col.fields":          "字段",
+	"col.bytes":           "字节数",
+	"col.go_type":         "Go类型",
+	"col.events":          "事件数",
+	"col.first_byte":      "首字节",
+	"col.event_types":     "事件类型",
+	"col.count":           "次数",
+	"col.params":          "参数",
+	"col.service_method":  "服务/方法",
+	"col.protocol":        "协议",
+	"col.request_bytes":   "请求字节",
+	"col.response_bytes":  "响应字节",
+	"col.messages":        "消息数",
+	"col.start":           "起始地址",
+	"col.final":           "最终地址",
+	"col.redirects":       "重定向次数",
+	"col.notes":           "说明",
+	"col.from":            "原地址",
+	"col.to":              "目标地址",
+	"col.reason":          "原因",
+	"col.cacheability":    "可缓存性",
+	"col.cacheable":       "可缓存",
+	"col.from_cache":      "缓存命中",
+	"col.revalidated":     "304 / 条件请求",
+	"col.downloads":       "下载次数",
+	"col.urls":            "地址数",
+	"col.duplicate_bytes": "重复字节",
+	"col.wasted_bytes":    "浪费的字节",
+	"col.wasted_time":     "浪费的耗时",
+	"col.original":        "原始大小",
+	"col.transferred":     "传输大小",
+	"col.ratio":           "压缩比",
+	"col.uncompressed":    "未压缩",
+	"col.savable":         "可节省",
+	"col.page":            "页面",
+	"col.render_blocking": "阻塞渲染",
+	"col.budget":          "预算",
+	"col.started":         "开始时间",
+	"col.finished":        "完成时间",
+
+	// 生成的代码
+	"codegen.api_endpoint":          "%s %s (调用%d次)",
+	"codegen.header_count":          "出现%d次",
+	"codegen.generated_from":        "由 UniversalHarAnalyzer 根据 %s 自动生成",
+	"codegen.run_hint":              "运行: %s",
+	"codegen.extract_comment":       "从响应JSON中按路径提取关联值",
+	"codegen.expand_comment":        "替换占位符为关联变量的值",
+	"codegen.run_iteration_comment": "执行一轮完整的用户旅程",
+	"codegen.total_line":            "总请求数: %d, 吞吐量: %.2f 请求/秒",
+	"codegen.rpc_method":            "调用 %s（%s，出现 %d 次）",
+
+	// 明细导出
+	"export.failed":      "导出明细数据失败: %v",
+	"export.sqlite_file": "SQLite数据库: %s",
+
+	// 过滤表达式
+	"filter.empty":               "过滤表达式为空",
+	"filter.syntax_error":        "过滤表达式第%d个字符附近语法错误: %s",
+	"filter.trailing":            "多余的内容 %q",
+	"filter.unterminated_string": "字符串缺少结束引号",
+	"filter.unknown_operator":    "未知运算符 %q",
+	"filter.unexpected_char":     "无法识别的字符 %q",
+	"filter.incomplete":          "表达式不完整",
+	"filter.unclosed_paren":      "括号未闭合",
+	"filter.expected_field":      "此处应为字段名，实际为 %q",
+	"filter.unknown_field":       "未知字段 %q",
+	"filter.missing_operator":    "字段 %q 后缺少比较运算符",
+	"filter.missing_value":       "运算符 %q 后缺少比较值",
+	"filter.numeric_operator":    "数值字段 %q 不支持运算符 %q",
+	"filter.expected_number":     "字段 %q 需要数值，实际为 %q",
+	"filter.expected_string":     "字段 %q 需要字符串，实际为 %q",
+	"filter.invalid_regex":       "无效的正则表达式: %v",
+	"filter.invalid_number":      "无效的数值 %q",
+	"filter.invalid_unit":        "字段 %q 不支持单位 %q",
+
+	// 压测脚本生成
+	"loadscript.failed":  "生成压测脚本失败: %v",
+	"loadscript.done":    "%s: %d个步骤, %d个关联变量",
+	"loadscript.k6_file": "k6脚本: %s",
+	"loadscript.go_file": "Go压测程序: %s",
+
+	// 压测
+	"load.failed":          "压测失败: %v",
+	"load.target_required": "必须通过 -target 指定目标地址",
+	"load.invalid_target":  "无效的目标地址: %s",
+	"load.invalid_vus":     "虚拟用户数必须大于0",
+	"load.invalid_scale":   "思考时间倍率不能为负数",
+	"load.har_required":    "需要指定一个HAR文件",
+	"load.no_requests":     "HAR文件中没有可回放的请求",
+	"load.multiple_hosts":  "发往 %s 的请求都会发送到 %s；可用 -filter 'host == \"...\"' 只回放应用自己的主机",
+	"load.start":           "开始压测: %s -> %s (%d个虚拟用户, %s)",
+	"load.done":            "压测完成: %d个请求, %d个错误, %.2f 请求/秒, P95 %.1fms",
+	"load.interrupted":     "压测在 %s 后被中断，保存已完成部分的结果",
+	"load.partial":         "压测在达到设定时长前被中断，结果不完整",
+	"load.report_title":    "HAR压测报告: %s",
+	"load.start_time":      "开始时间",
+	"load.target":          "目标地址",
+	"load.vus":             "虚拟用户数",
+	"load.duration":        "持续时间",
+	"load.think_scale":     "思考时间倍率",
+	"load.iterations":      "完成迭代数",
+	"load.errors":          "错误数",
+	"load.throughput":      "吞吐量",
+	"load.rps":             "%.2f 请求/秒",
+	"load.latency":         "延迟统计 (毫秒)",
+	"load.endpoints":       "端点统计",
+
+	// 配置文件
+	"config.read_failed":             "读取配置文件失败",
+	"config.parse_failed":            "解析配置文件 %s 失败",
+	"config.unknown_field":           "未知字段 %s",
+	"config.unsupported_format":      "不支持的配置文件格式: %s（可选: .yaml, .yml, .toml）",
+	"config.invalid":                 "配置文件 %s 无效",
+	"config.empty_value":             "%s 中不能包含空值",
+	"config.negative_threshold":      "thresholds.%s 不能为负数",
+	"config.invalid_max_items":       "maxItems 必须大于0",
+	"config.invalid_content_type":    "contentTypes[%d] 必须同时指定 type 和 match",
+	"config.invalid_redaction":       "redact[%d] 必须且只能指定 header、param、cookie、value 之一",
+	"config.invalid_redaction_regex": "redact[%d] 正则表达式无效: %v",
+	"config.invalid_page_budget":     "pageBudgets[%d] 的限制不能为负数",
+	"config.unknown_section":         "sections 中的未知章节 %q（可选: %s）",
+	"config.invalid_watch_interval":  "watch.interval 必须大于0",
+	"config.negative_duration":       "%s 不能为负数",
+	"grpc.descriptor_read_failed":    "读取protobuf描述符集合 %s 失败",
+	"grpc.descriptor_invalid":        "无效的protobuf描述符集合 %s",
+
+	// 报告模板
+	"template.read_failed":       "读取报告模板 %s 失败",
+	"template.parse_failed":      "解析报告模板 %s 失败",
+	"template.render_failed":     "渲染报告模板 %s 失败",
+	"template.unknown_threshold": "未知的阈值 %q",
+	"template.unknown_field":     "未知字段 %q",
+	"template.not_a_list":        "%s 需要列表参数",
+	"template.not_a_map":         "%s 需要map参数",
+	"template.invalid_dict":      "dict 参数必须为成对的字符串键和值",
+
+	// 输出文件
+	"output.unsupported_naming": "不支持的命名方式: %s（可选: %s）",
+	"output.unsupported_mode":   "不支持的写入模式: %s（可选: %s）",
+	"output.manifest_failed":    "写入输出清单失败: %v",
+
+	// 分析结果缓存
+	"cache.hit":          "文件未变化，使用缓存的分析结果",
+	"cache.store_failed": "写入分析结果缓存失败: %v",
+	"cache.summary":      "重新分析 %d 个文件，%d 个文件使用缓存",
+
+	// 目录监视
+	"watch.failed":          "监视失败: %v",
+	"watch.usage":           "用法: %s watch [选项] [目录...]",
+	"watch.not_directory":   "%s 不是目录",
+	"watch.start":           "开始监视 %s（每 %s 检查一次，按 Ctrl+C 停止）",
+	"watch.scan_failed":     "扫描 %s 失败: %v",
+	"watch.removed":         "文件已删除，从汇总报告中移除: %s",
+	"watch.summary_updated": "汇总报告已更新（%d 个文件）",
+	"watch.gate_running":    "执行检查命令: %s",
+	"watch.gate_passed":     "检查命令通过",
+	"watch.gate_failed":     "检查命令未通过: %v",
+	"watch.stopping":        "正在停止监视...",
+	"watch.stopped":         "监视已停止",
+
+	// Web界面
+	"ui.failed":         "Web界面启动失败: %v",
+	"ui.usage":          "用法: %s ui [选项] [HAR目录]",
+	"ui.listening":      "Web界面已启动: %s（HAR目录: %s，按 Ctrl+C 停止）",
+	"ui.file_not_found": "文件不存在: %s",
+	"ui.forbidden_host": "不允许的主机: %s（请通过 %s、localhost 或 127.0.0.1 访问）",
+
+	// Web界面文本
+	"web.title":            "通用HAR分析器",
+	"web.files":            "HAR文件",
+	"web.upload":           "上传HAR文件",
+	"web.uploading":        "正在上传...",
+	"web.uploaded":         "已上传",
+	"web.no_files":         "目录中没有HAR文件",
+	"web.select_file":      "从左侧选择或上传一个HAR文件",
+	"web.loading":          "加载中...",
+	"web.error":            "出错: %s",
+	"web.filter":           "过滤...",
+	"web.apis":             "API",
+	"web.parameters":       "参数",
+	"web.headers":          "请求头",
+	"web.downloads":        "下载",
+	"web.calls":            "调用记录: %s %s",
+	"web.time":             "耗时",
+	"web.size":             "大小",
+	"web.value":            "值",
+	"web.request_headers":  "请求头",
+	"web.request_body":     "请求体",
+	"web.response_headers": "响应头",
+	"web.response_body":    "响应体",
+	"web.empty":            "（空）",
+	"web.compare":          "文件对比",
+	"web.compare_run":      "对比",
+	"web.compare_title":    "对比: %s ↔ %s",
+
+	// 终端界面
+	"tui.failed":            "终端界面运行失败: %v",
+	"tui.usage":             "用法: %s tui [选项] <HAR文件或目录>...",
+	"tui.title":             " 通用HAR分析器 · %s · %d/%d 个请求",
+	"tui.api_filter":        "[API: %s %s]",
+	"tui.no_entries":        "没有匹配的请求",
+	"tui.help_entries":      "↑↓/jk 移动  Tab 查看详情  / 过滤  s 排序  r 反序  a API表  Esc 清除  q 退出",
+	"tui.help_apis":         "↑↓/jk 移动  Enter 查看调用  Esc 返回  q 退出",
+	"tui.filter_editing":    "过滤: %s▏（Enter 确定，Esc 清除）",
+	"tui.filter_status":     "过滤(%s): %s",
+	"tui.filter_text":       "文本",
+	"tui.filter_expression": "表达式",
+	"tui.sort_status":       "排序: %s%s",
+
+	// SQL查询
+	"query.failed":             "查询失败: %v",
+	"query.usage":              `用法: %s query [选项] "SQL" [HAR文件或目录...]`,
+	"query.missing_sql":        "缺少SQL语句",
+	"query.unsupported_format": "不支持的输出格式: %s",
+	"query.import_failed":      "导入 %s 失败",
+	"query.row_count":          "(%d行)",
+}
@@ -0,0 +1,137 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+// 格式化动词（%% 不计入）
+var messageVerbPattern = regexp.MustCompile(`%[-+# 0-9.]*[a-zA-Z]`)
+
+// 源码中引用消息键的位置：T("key")、syntaxError(tok, "key")、生成代码中的 {{key}}
+var messageKeyUsagePatterns = []*regexp.Regexp{
+	regexp.MustCompile(`\bT\("([a-z0-9_.]+)"`),
+	regexp.MustCompile(`syntaxError\([^,]+, "([a-z0-9_.]+)"`),
+	codeTemplateMessagePattern,
+}
+
+// Web界面中引用消息键的位置：t('key')、data-t="key"
+var webMessageKeyUsagePatterns = []*regexp.Regexp{
+	regexp.MustCompile(`\bt\('([a-z0-9_.]+)'`),
+	regexp.MustCompile(`data-t="([a-z0-9_.]+)"`),
+}
+
+func messageVerbs(message string) []string {
+	return messageVerbPattern.FindAllString(strings.ReplaceAll(message, "%%", ""), -1)
+}
+
+func TestCatalogsHaveSameKeys(t *testing.T) {
+	for lang, catalog := range messageCatalogs {
+		for otherLang, other := range messageCatalogs {
+			for key := range catalog {
+				if _, ok := other[key]; !ok {
+					t.Errorf("key %q exists in %s catalog but is missing from %s catalog", key, lang, otherLang)
+				}
+			}
+		}
+	}
+}
+
+func TestCatalogsHaveMatchingVerbs(t *testing.T) {
+	for key, message := range zhMessages {
+		for lang, catalog := range messageCatalogs {
+			translated, ok := catalog[key]
+			if !ok {
+				continue
+			}
+			want := strings.Join(messageVerbs(message), " ")
+			if got := strings.Join(messageVerbs(translated), " "); got != want {
+				t.Errorf("%s message %q has verbs [%s], want [%s]", lang, key, got, want)
+			}
+		}
+	}
+}
+
+func TestSourceMessageKeysExist(t *testing.T) {
+	files, err := filepath.Glob("*.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	webFiles, err := filepath.Glob("web/*")
+	if err != nil {
+		t.Fatal(err)
+	}
+	used := 0
+	for _, file := range append(files, webFiles...) {
+		if strings.HasSuffix(file, "_test.go") {
+			continue
+		}
+		source, err := os.ReadFile(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		patterns := messageKeyUsagePatterns
+		if strings.HasPrefix(filepath.ToSlash(file), "web/") {
+			patterns = webMessageKeyUsagePatterns
+		}
+		for _, pattern := range patterns {
+			for _, match := range pattern.FindAllStringSubmatch(string(source), -1) {
+				used++
+				for lang, catalog := range messageCatalogs {
+					if _, ok := catalog[match[1]]; !ok {
+						t.Errorf("%s uses message %q which is missing from %s catalog", file, match[1], lang)
+					}
+				}
+			}
+		}
+	}
+	if used == 0 {
+		t.Fatal("no message keys found in source files")
+	}
+}
+
+func TestLanguageSelection(t *testing.T) {
+	defer func() { currentLanguage = "zh" }()
+
+	tests := []struct {
+		env  string
+		args []string
+		want string
+	}{
+		{"", nil, "zh"},
+		{"en_US.UTF-8", nil, "en"},
+		{"zh_CN.UTF-8", nil, "zh"},
+		{"C.UTF-8", nil, "zh"},
+		{"zh_CN.UTF-8", []string{"-lang", "en"}, "en"},
+		{"en_US.UTF-8", []string{"-o", "out", "--lang=zh"}, "zh"},
+		{"en_US.UTF-8", []string{"--", "-lang", "zh"}, "en"},
+	}
+	for _, tt := range tests {
+		t.Setenv("LC_ALL", "")
+		t.Setenv("LC_MESSAGES", "")
+		t.Setenv("LANG", tt.env)
+		if err := initLanguage(tt.args); err != nil {
+			t.Fatalf("initLanguage(%q) with LANG=%q: %v", tt.args, tt.env, err)
+		}
+		if currentLanguage != tt.want {
+			t.Errorf("initLanguage(%q) with LANG=%q = %s, want %s", tt.args, tt.env, currentLanguage, tt.want)
+		}
+	}
+
+	if err := initLanguage([]string{"-lang", "fr"}); err == nil {
+		t.Error("initLanguage accepted unsupported language fr")
+	}
+}
+
+func TestLocalizeCodeTemplate(t *testing.T) {
+	defer func() { currentLanguage = "zh" }()
+
+	currentLanguage = "en"
+	got := localizeCodeTemplate(`flag.Int("vus", 10, "{{flag.vus}}")`)
+	if want := `flag.Int("vus", 10, "number of virtual users")`; got != want {
+		t.Errorf("localizeCodeTemplate = %s, want %s", got, want)
+	}
+}
@@ -0,0 +1,273 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// 输出文件命名方式: path 按相对路径命名，hash 按文件名加内容哈希命名
+var supportedNamingModes = []string{"path", "hash"}
+
+// 输出文件写入模式: overwrite 覆盖同名文件，timestamp 追加运行时间戳，version 追加递增版本号
+var supportedOutputModes = []string{"overwrite", "timestamp", "version"}
+
+// 输出清单文件名
+const manifestFileName = "manifest.json"
+
+// 输出清单（列出本次运行生成的所有文件）
+type OutputManifest struct {
+	GeneratedAt time.Time        `json:"generatedAt"`
+	Naming      string           `json:"naming"`
+	Mode        string           `json:"mode"`
+	Artifacts   []OutputArtifact `json:"artifacts"`
+}
+
+// 生成的文件
+type OutputArtifact struct {
+	Path   string `json:"path"`             // 相对输出目录的路径
+	Kind   string `json:"kind"`             // analysis、report、entries、sqlite、summary、load、k6、loadgen
+	Source string `json:"source,omitempty"` // 对应的HAR文件
+	Size   int64  `json:"size"`
+	SHA256 string `json:"sha256"`
+}
+
+// 注册 -naming 和 -output-mode 参数
+func addOutputNamingFlags(flags *flag.FlagSet) {
+	flags.String("naming", supportedNamingModes[0], T("flag.naming", strings.Join(supportedNamingModes, ", ")))
+	flags.String("output-mode", supportedOutputModes[0], T("flag.output_mode", strings.Join(supportedOutputModes, ", ")))
+}
+
+// 设置输出文件命名方式和写入模式
+func (ua *UniversalHARAnalyzer) SetOutputNaming(naming, mode string) error {
+	if !containsString(supportedNamingModes, naming) {
+		return fmt.Errorf("%s", T("output.unsupported_naming", naming, strings.Join(supportedNamingModes, ", ")))
+	}
+	if !containsString(supportedOutputModes, mode) {
+		return fmt.Errorf("%s", T("output.unsupported_mode", mode, strings.Join(supportedOutputModes, ", ")))
+	}
+	ua.naming = naming
+	ua.outputMode = mode
+	return nil
+}
+
+// 开始一次输出（记录运行时间戳并清空清单）
+func (ua *UniversalHARAnalyzer) beginOutput(roots ...string) {
+	if ua.naming == "" {
+		ua.naming = supportedNamingModes[0]
+	}
+	if ua.outputMode == "" {
+		ua.outputMode = supportedOutputModes[0]
+	}
+	ua.scanRoots = roots
+	ua.runTimestamp = time.Now().Unix()
+	ua.baseNames = make(map[string]string)
+	ua.versions = make(map[string]int)
+	ua.manifest = &OutputManifest{GeneratedAt: time.Now(), Naming: ua.naming, Mode: ua.outputMode}
+}
+
+// 命令行参数对应的扫描目录（文件取所在目录，没有参数时为当前目录）
+func harFileRoots(paths []string) []string {
+	if len(paths) == 0 {
+		currentDir, _ := os.Getwd()
+		return []string{currentDir}
+	}
+	var roots []string
+	for _, path := range paths {
+		if info, err := os.Stat(path); err == nil && !info.IsDir() {
+			path = filepath.Dir(path)
+		}
+		if !containsString(roots, path) {
+			roots = append(roots, path)
+		}
+	}
+	return roots
+}
+
+// HAR文件相对扫描目录的路径（扫描多个目录时以目录名开头）
+func (ua *UniversalHARAnalyzer) relativeHARPath(filePath string) string {
+	for _, root := range ua.scanRoots {
+		rel, err := filepath.Rel(root, filePath)
+		if err != nil || strings.HasPrefix(rel, "..") {
+			continue
+		}
+		if len(ua.scanRoots) > 1 {
+			rel = filepath.Join(filepath.Base(root), rel)
+		}
+		return filepath.ToSlash(rel)
+	}
+	return filepath.Base(filePath)
+}
+
+// 输出文件的基础名（不含类型和扩展名）
+func (ua *UniversalHARAnalyzer) outputBaseName(filePath string) (string, error) {
+	if baseName, ok := ua.baseNames[filePath]; ok {
+		return baseName, nil
+	}
+
+	rel := strings.TrimSuffix(ua.relativeHARPath(filePath), filepath.Ext(filePath))
+	baseName := sanitizeFileName(rel)
+	if ua.naming == "hash" {
+		hash, err := fileSHA256(filePath)
+		if err != nil {
+			return "", err
+		}
+		baseName = sanitizeFileName(filepath.Base(rel)) + "_" + hash[:12]
+	}
+	// 替换特殊字符后可能与其他文件重名（如 a/x.har 和 a_x.har），追加相对路径的短哈希
+	if ua.baseNameInUse(baseName, filePath) {
+		sum := sha256.Sum256([]byte(ua.relativeHARPath(filePath)))
+		baseName += "_" + hex.EncodeToString(sum[:])[:8]
+	}
+
+	if ua.baseNames != nil {
+		ua.baseNames[filePath] = baseName
+	}
+	return baseName, nil
+}
+
+// 基础名是否已被本次运行中的其他HAR文件或汇总报告使用
+func (ua *UniversalHARAnalyzer) baseNameInUse(baseName, filePath string) bool {
+	if baseName == summaryBaseName {
+		return true
+	}
+	for path, used := range ua.baseNames {
+		if used == baseName && path != filePath {
+			return true
+		}
+	}
+	return false
+}
+
+// 将路径转换为安全的文件名（路径分隔符和特殊字符替换为下划线）
+func sanitizeFileName(name string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) || r == '-' || r == '.' {
+			return r
+		}
+		return '_'
+	}, name)
+}
+
+// 按写入模式生成输出文件路径，如 orders_analysis.json、orders_analysis_1700000000.json、orders_analysis_v3.json
+func (ua *UniversalHARAnalyzer) artifactPath(base, kind, ext string) string {
+	name := base + "_" + kind
+	switch ua.outputMode {
+	case "timestamp":
+		name += "_" + strconv.FormatInt(ua.runTimestamp, 10)
+	case "version":
+		name += "_v" + strconv.Itoa(ua.nextVersion(base))
+	}
+	return filepath.Join(ua.outputDir, name+ext)
+}
+
+// 同一HAR文件的所有输出共用一个版本号（输出目录中已有的最大版本号加1，类型可含数字，如 k6；目录没有扩展名，如 x_loadgen_v2）
+func (ua *UniversalHARAnalyzer) nextVersion(base string) int {
+	if ua.versions == nil {
+		ua.versions = make(map[string]int)
+	}
+	if version, ok := ua.versions[base]; ok {
+		return version
+	}
+
+	version := 1
+	pattern := regexp.MustCompile(`^` + regexp.QuoteMeta(base) + `_[a-z0-9]+_v(\d+)(\.|$)`)
+	if entries, err := os.ReadDir(ua.outputDir); err == nil {
+		for _, entry := range entries {
+			if match := pattern.FindStringSubmatch(entry.Name()); match != nil {
+				if existing, err := strconv.Atoi(match[1]); err == nil && existing >= version {
+					version = existing + 1
+				}
+			}
+		}
+	}
+	ua.versions[base] = version
+	return version
+}
+
+// 记录生成的文件
+func (ua *UniversalHARAnalyzer) recordArtifact(path, kind, source string) error {
+	if ua.manifest == nil {
+		return nil
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+	hash, err := fileSHA256(path)
+	if err != nil {
+		return err
+	}
+
+	rel, err := filepath.Rel(ua.outputDir, path)
+	if err != nil {
+		rel = path
+	}
+	if source != "" {
+		source = ua.relativeHARPath(source)
+	}
+	artifact := OutputArtifact{
+		Path:   filepath.ToSlash(rel),
+		Kind:   kind,
+		Source: source,
+		Size:   info.Size(),
+		SHA256: hash,
+	}
+
+	// 同一文件被重新写入时更新原有记录
+	for i := range ua.manifest.Artifacts {
+		if ua.manifest.Artifacts[i].Path == artifact.Path {
+			ua.manifest.Artifacts[i] = artifact
+			return nil
+		}
+	}
+	ua.manifest.Artifacts = append(ua.manifest.Artifacts, artifact)
+	return nil
+}
+
+// 写入输出清单
+func (ua *UniversalHARAnalyzer) writeManifest() error {
+	if ua.manifest == nil {
+		return nil
+	}
+	data, err := json.MarshalIndent(ua.manifest, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(ua.outputDir, manifestFileName), data, 0644)
+}
+
+// 计算文件内容的SHA-256
+func fileSHA256(path string) (string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, file); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// 判断字符串是否在列表中
+func containsString(list []string, value string) bool {
+	for _, candidate := range list {
+		if candidate == value {
+			return true
+		}
+	}
+	return false
+}
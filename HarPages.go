@@ -0,0 +1,222 @@
+package main
+
+import (
+	"sort"
+	"strings"
+	"time"
+)
+
+// 每个页面列出的最大和最慢资源数
+const pageTopResources = 5
+
+// 页面性能分析结果
+type PageAnalysis struct {
+	Pages []PagePerformance `json:"pages"`
+}
+
+// 单个页面的加载性能
+type PagePerformance struct {
+	ID               string             `json:"id"`
+	Title            string             `json:"title"`
+	URL              string             `json:"url,omitempty"` // 页面文档地址（第一个2xx文档请求）
+	Requests         int                `json:"requests"`
+	TransferBytes    int64              `json:"transferBytes"`
+	DOMContentLoaded float64            `json:"domContentLoaded,omitempty"` // 相对页面开始的时间（毫秒），未记录时为0
+	OnLoad           float64            `json:"onLoad,omitempty"`
+	ResourceTypes    []PageResourceType `json:"resourceTypes"`            // 按资源类型统计的请求数和传输字节数
+	CriticalPath     []PageResource     `json:"criticalPath,omitempty"`   // onLoad 之前完成的请求（按完成时间）
+	RenderBlocking   []PageResource     `json:"renderBlocking,omitempty"` // 阻塞渲染的样式和脚本
+	Largest          []PageResource     `json:"largest"`
+	Slowest          []PageResource     `json:"slowest"`
+}
+
+// 页面中一种资源类型的统计
+type PageResourceType struct {
+	Kind     string `json:"kind"`
+	Requests int    `json:"requests"`
+	Bytes    int64  `json:"bytes"`
+}
+
+// 页面中的一个请求
+type PageResource struct {
+	URL   string  `json:"url"`
+	Kind  string  `json:"kind"`
+	Bytes int64   `json:"bytes"`
+	Time  float64 `json:"time"`  // 请求耗时（毫秒）
+	Start float64 `json:"start"` // 相对页面开始的时间（毫秒）
+	End   float64 `json:"end"`
+}
+
+// onLoad 之前完成的请求的传输字节数
+func (p PagePerformance) CriticalBytes() int64 {
+	var total int64
+	for _, resource := range p.CriticalPath {
+		total += resource.Bytes
+	}
+	return total
+}
+
+// 关键路径中最后完成的请求（决定 onLoad 时间的请求在前）
+func (p PagePerformance) CriticalTail() []PageResource {
+	tail := append([]PageResource(nil), p.CriticalPath...)
+	for i, j := 0, len(tail)-1; i < j; i, j = i+1, j-1 {
+		tail[i], tail[j] = tail[j], tail[i]
+	}
+	return tail
+}
+
+// 页面的显示名称（没有标题时为页面ID）
+func (p PagePerformance) Name() string {
+	if p.Title != "" {
+		return p.Title
+	}
+	return p.ID
+}
+
+// 是否为阻塞渲染的请求：DOMContentLoaded 之前开始的样式表和同步脚本（有 _priority 时只计高优先级的请求，async/defer 脚本优先级较低）
+func isRenderBlocking(entry *HAREntry, start, domContentLoaded float64) bool {
+	kind := resourceKind(entry)
+	if kind != "stylesheet" && kind != "script" {
+		return false
+	}
+	if domContentLoaded > 0 && start >= domContentLoaded {
+		return false
+	}
+	switch strings.ToLower(entry.Priority) {
+	case "", "veryhigh", "highest", "high":
+		return true
+	}
+	return false
+}
+
+// 分析每个页面的加载性能（HAR中没有页面信息时返回nil）
+func (ua *UniversalHARAnalyzer) analyzePages(pages []HARPage, entries []HAREntry) *PageAnalysis {
+	if len(pages) == 0 {
+		return nil
+	}
+	pageEntries := make(map[string][]*HAREntry)
+	for i := range entries {
+		if entries[i].Pageref != "" {
+			pageEntries[entries[i].Pageref] = append(pageEntries[entries[i].Pageref], &entries[i])
+		}
+	}
+
+	analysis := &PageAnalysis{}
+	for _, page := range pages {
+		performance := PagePerformance{ID: page.ID, Title: page.Title}
+		if page.PageTimings.OnContentLoad > 0 {
+			performance.DOMContentLoaded = roundMillis(page.PageTimings.OnContentLoad)
+		}
+		if page.PageTimings.OnLoad > 0 {
+			performance.OnLoad = roundMillis(page.PageTimings.OnLoad)
+		}
+		pageStart, pageStartErr := time.Parse(time.RFC3339, page.StartedDateTime)
+
+		types := make(map[string]*PageResourceType)
+		var resources []PageResource
+		var firstDocument string
+		for _, entry := range pageEntries[page.ID] {
+			kind := resourceKind(entry)
+			size := transferBytes(entry)
+			if servedFromCache(entry) {
+				size = 0
+			}
+			resource := PageResource{URL: ua.redactURL(entry.Request.URL), Kind: kind, Bytes: size, Time: roundMillis(entry.Time)}
+			if started, err := time.Parse(time.RFC3339, entry.StartedDateTime); err == nil && pageStartErr == nil {
+				resource.Start = roundMillis(float64(started.Sub(pageStart)) / float64(time.Millisecond))
+			}
+			resource.End = roundMillis(resource.Start + entry.Time)
+			// 页面地址取第一个成功的文档请求（跳过 http→https 等重定向）
+			if kind == "document" {
+				if firstDocument == "" {
+					firstDocument = resource.URL
+				}
+				if performance.URL == "" && entry.Response.Status >= 200 && entry.Response.Status < 300 {
+					performance.URL = resource.URL
+				}
+			}
+
+			performance.Requests++
+			performance.TransferBytes += size
+			if _, exists := types[kind]; !exists {
+				types[kind] = &PageResourceType{Kind: kind}
+			}
+			types[kind].Requests++
+			types[kind].Bytes += size
+
+			if performance.OnLoad > 0 && resource.End <= performance.OnLoad {
+				performance.CriticalPath = append(performance.CriticalPath, resource)
+			}
+			if isRenderBlocking(entry, resource.Start, performance.DOMContentLoaded) {
+				performance.RenderBlocking = append(performance.RenderBlocking, resource)
+			}
+			resources = append(resources, resource)
+		}
+		if performance.URL == "" {
+			performance.URL = firstDocument
+		}
+
+		for _, resourceType := range types {
+			performance.ResourceTypes = append(performance.ResourceTypes, *resourceType)
+		}
+		sort.Slice(performance.ResourceTypes, func(i, j int) bool {
+			a, b := performance.ResourceTypes[i], performance.ResourceTypes[j]
+			if a.Bytes != b.Bytes {
+				return a.Bytes > b.Bytes
+			}
+			return a.Kind < b.Kind
+		})
+		sort.SliceStable(performance.CriticalPath, func(i, j int) bool {
+			return performance.CriticalPath[i].End < performance.CriticalPath[j].End
+		})
+		performance.Largest = topPageResources(resources, func(a, b PageResource) bool { return a.Bytes > b.Bytes })
+		performance.Slowest = topPageResources(resources, func(a, b PageResource) bool { return a.Time > b.Time })
+		analysis.Pages = append(analysis.Pages, performance)
+	}
+	return analysis
+}
+
+// 按指定顺序取前几个资源
+func topPageResources(resources []PageResource, less func(a, b PageResource) bool) []PageResource {
+	sorted := append([]PageResource(nil), resources...)
+	sort.SliceStable(sorted, func(i, j int) bool { return less(sorted[i], sorted[j]) })
+	if len(sorted) > pageTopResources {
+		sorted = sorted[:pageTopResources]
+	}
+	return sorted
+}
+
+// 页面适用的性能预算（按配置顺序取第一个匹配页面标题或地址的预算）
+func (ua *UniversalHARAnalyzer) pageBudget(page PagePerformance) *PageBudget {
+	for i, budget := range ua.settings().PageBudgets {
+		if budget.Page == "" || strings.Contains(page.Title, budget.Page) || strings.Contains(page.URL, budget.Page) {
+			return &ua.settings().PageBudgets[i]
+		}
+	}
+	return nil
+}
+
+// 页面超出预算的项（没有预算或没有超出时为空）
+func (ua *UniversalHARAnalyzer) budgetViolations(page PagePerformance) []string {
+	budget := ua.pageBudget(page)
+	if budget == nil {
+		return nil
+	}
+	var violations []string
+	if budget.Requests > 0 && page.Requests > budget.Requests {
+		violations = append(violations, T("page.budget_requests", page.Requests, budget.Requests))
+	}
+	if budget.TransferBytes > 0 && page.TransferBytes > budget.TransferBytes {
+		violations = append(violations, T("page.budget_bytes", formatByteSize(page.TransferBytes), formatByteSize(budget.TransferBytes)))
+	}
+	if budget.DOMContentLoaded > 0 && page.DOMContentLoaded > budget.DOMContentLoaded {
+		violations = append(violations, T("page.budget_dom_content_loaded", formatDurationMillis(page.DOMContentLoaded), formatDurationMillis(budget.DOMContentLoaded)))
+	}
+	if budget.OnLoad > 0 && page.OnLoad > budget.OnLoad {
+		violations = append(violations, T("page.budget_onload", formatDurationMillis(page.OnLoad), formatDurationMillis(budget.OnLoad)))
+	}
+	if budget.RenderBlocking > 0 && len(page.RenderBlocking) > budget.RenderBlocking {
+		violations = append(violations, T("page.budget_render_blocking", len(page.RenderBlocking), budget.RenderBlocking))
+	}
+	return violations
+}
@@ -0,0 +1,223 @@
+package main
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+// 无模式解码时嵌套消息的最大深度
+const maxProtoDepth = 16
+
+// protobuf 线路类型
+const (
+	protoWireVarint  = 0
+	protoWireFixed64 = 1
+	protoWireBytes   = 2
+	protoWireFixed32 = 5
+)
+
+// 无模式解码出的字段（按字段号组成的树）
+type protoField struct {
+	number   int
+	wireType int
+	value    uint64       // varint、fixed32、fixed64 的值
+	data     []byte       // 长度分隔字段的原始内容
+	message  []protoField // 长度分隔字段能解析为嵌套消息时的字段
+	isString bool         // 长度分隔字段是可打印文本
+}
+
+// 按线路格式解码protobuf消息（不需要.proto定义），数据不是有效的protobuf消息时返回false
+func decodeProtoWire(data []byte, depth int) ([]protoField, bool) {
+	if depth > maxProtoDepth {
+		return nil, false
+	}
+	var fields []protoField
+	for len(data) > 0 {
+		key, n := binary.Uvarint(data)
+		if n <= 0 {
+			return nil, false
+		}
+		data = data[n:]
+		field := protoField{number: int(key >> 3), wireType: int(key & 7)}
+		if field.number < 1 || field.number > 1<<29-1 {
+			return nil, false
+		}
+
+		switch field.wireType {
+		case protoWireVarint:
+			value, n := binary.Uvarint(data)
+			if n <= 0 {
+				return nil, false
+			}
+			field.value, data = value, data[n:]
+		case protoWireFixed64:
+			if len(data) < 8 {
+				return nil, false
+			}
+			field.value, data = binary.LittleEndian.Uint64(data), data[8:]
+		case protoWireFixed32:
+			if len(data) < 4 {
+				return nil, false
+			}
+			field.value, data = uint64(binary.LittleEndian.Uint32(data)), data[4:]
+		case protoWireBytes:
+			length, n := binary.Uvarint(data)
+			if n <= 0 || length > uint64(len(data)-n) {
+				return nil, false
+			}
+			field.data, data = data[n:n+int(length)], data[n+int(length):]
+			// 可打印文本优先按字符串显示，否则尝试解析为嵌套消息
+			if isPrintableText(field.data) {
+				field.isString = true
+			} else if message, ok := decodeProtoWire(field.data, depth+1); ok && len(message) > 0 {
+				field.message = message
+			}
+		default:
+			// 已废弃的 group 类型和非法类型
+			return nil, false
+		}
+		fields = append(fields, field)
+	}
+	return fields, true
+}
+
+// 是否为可打印的UTF-8文本
+func isPrintableText(data []byte) bool {
+	if len(data) == 0 || !utf8.Valid(data) {
+		return false
+	}
+	for _, r := range string(data) {
+		if !unicode.IsPrint(r) && !unicode.IsSpace(r) {
+			return false
+		}
+	}
+	return true
+}
+
+// 以文本形式显示无模式解码的字段树（1: 150、2: "text"、3 { ... }）
+func formatProtoFields(fields []protoField, indent string) string {
+	var text strings.Builder
+	for _, field := range fields {
+		switch {
+		case field.message != nil:
+			fmt.Fprintf(&text, "%s%d {\n%s%s}\n", indent, field.number, formatProtoFields(field.message, indent+"  "), indent)
+		case field.isString:
+			fmt.Fprintf(&text, "%s%d: %q\n", indent, field.number, string(field.data))
+		case field.wireType == protoWireBytes:
+			fmt.Fprintf(&text, "%s%d: %s\n", indent, field.number, formatProtoBytes(field.data))
+		case field.wireType == protoWireFixed64:
+			fmt.Fprintf(&text, "%s%d: 0x%016x (double %g)\n", indent, field.number, field.value, math.Float64frombits(field.value))
+		case field.wireType == protoWireFixed32:
+			fmt.Fprintf(&text, "%s%d: 0x%08x (float %g)\n", indent, field.number, field.value, math.Float32frombits(uint32(field.value)))
+		default:
+			fmt.Fprintf(&text, "%s%d: %d\n", indent, field.number, field.value)
+		}
+	}
+	return text.String()
+}
+
+// 二进制字段显示为十六进制（过长时截断）
+func formatProtoBytes(data []byte) string {
+	const maxBytes = 32
+	if len(data) > maxBytes {
+		return fmt.Sprintf("0x%s… (%d bytes)", hex.EncodeToString(data[:maxBytes]), len(data))
+	}
+	return "0x" + hex.EncodeToString(data)
+}
+
+// 用户提供的 .proto 描述符集合（protoc --descriptor_set_out --include_imports 生成）
+type protoDescriptorSet struct {
+	files       *protoregistry.Files
+	fingerprint string // 描述符文件内容的哈希，用于缓存
+}
+
+// 加载并合并多个描述符集合文件
+func loadProtoDescriptors(paths []string) (*protoDescriptorSet, error) {
+	merged := &descriptorpb.FileDescriptorSet{}
+	seen := make(map[string]bool)
+	hash := sha256.New()
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", T("grpc.descriptor_read_failed", path), err)
+		}
+		hash.Write(data)
+
+		set := &descriptorpb.FileDescriptorSet{}
+		if err := proto.Unmarshal(data, set); err != nil {
+			return nil, fmt.Errorf("%s: %w", T("grpc.descriptor_invalid", path), err)
+		}
+		for _, file := range set.GetFile() {
+			if !seen[file.GetName()] {
+				seen[file.GetName()] = true
+				merged.File = append(merged.File, file)
+			}
+		}
+	}
+
+	files, err := protodesc.NewFiles(merged)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", T("grpc.descriptor_invalid", strings.Join(paths, ", ")), err)
+	}
+	return &protoDescriptorSet{files: files, fingerprint: hex.EncodeToString(hash.Sum(nil))}, nil
+}
+
+// 描述符集合的指纹（未加载时为空）
+func (s *protoDescriptorSet) fingerprintOrEmpty() string {
+	if s == nil {
+		return ""
+	}
+	return s.fingerprint
+}
+
+// 查找gRPC方法的请求和响应消息类型
+func (s *protoDescriptorSet) method(service, method string) (protoreflect.MethodDescriptor, bool) {
+	if s == nil {
+		return nil, false
+	}
+	descriptor, err := s.files.FindDescriptorByName(protoreflect.FullName(service))
+	if err != nil {
+		return nil, false
+	}
+	serviceDescriptor, ok := descriptor.(protoreflect.ServiceDescriptor)
+	if !ok {
+		return nil, false
+	}
+	methodDescriptor := serviceDescriptor.Methods().ByName(protoreflect.Name(method))
+	return methodDescriptor, methodDescriptor != nil
+}
+
+// 按消息类型解码并转换为格式化的JSON
+func decodeProtoMessage(data []byte, descriptor protoreflect.MessageDescriptor) (string, error) {
+	message := dynamicpb.NewMessage(descriptor)
+	if err := proto.Unmarshal(data, message); err != nil {
+		return "", err
+	}
+	encoded, err := protojson.Marshal(message)
+	if err != nil {
+		return "", err
+	}
+	// protojson 的输出空白不稳定，重新格式化
+	var formatted bytes.Buffer
+	if err := json.Indent(&formatted, encoded, "", "  "); err != nil {
+		return "", err
+	}
+	return formatted.String(), nil
+}
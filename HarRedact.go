@@ -0,0 +1,82 @@
+package main
+
+import (
+	"net/url"
+	"strings"
+)
+
+// 请求头/响应头脱敏
+func (ua *UniversalHARAnalyzer) redactHeader(name, value string) string {
+	for _, rule := range ua.settings().Redact {
+		if rule.Header != "" && strings.EqualFold(rule.Header, name) {
+			return rule.Replacement
+		}
+	}
+	return ua.redactValue(value)
+}
+
+// 查询参数脱敏
+func (ua *UniversalHARAnalyzer) redactParam(name, value string) string {
+	for _, rule := range ua.settings().Redact {
+		if rule.Param != "" && rule.Param == name {
+			return rule.Replacement
+		}
+	}
+	return ua.redactValue(value)
+}
+
+// Cookie脱敏
+func (ua *UniversalHARAnalyzer) redactCookie(name, value string) string {
+	for _, rule := range ua.settings().Redact {
+		if rule.Cookie != "" && rule.Cookie == name {
+			return rule.Replacement
+		}
+	}
+	return ua.redactValue(value)
+}
+
+// 按正则规则替换任意值中的敏感内容
+func (ua *UniversalHARAnalyzer) redactValue(value string) string {
+	for _, rule := range ua.settings().Redact {
+		if rule.pattern != nil {
+			value = rule.pattern.ReplaceAllString(value, rule.Replacement)
+		}
+	}
+	return value
+}
+
+// URL脱敏（替换匹配规则的查询参数值，保持参数顺序）
+func (ua *UniversalHARAnalyzer) redactURL(rawURL string) string {
+	if len(ua.settings().Redact) == 0 {
+		return rawURL
+	}
+
+	if queryStart := strings.Index(rawURL, "?"); queryStart >= 0 {
+		query := rawURL[queryStart+1:]
+		fragment := ""
+		if hash := strings.Index(query, "#"); hash >= 0 {
+			query, fragment = query[:hash], query[hash:]
+		}
+
+		pairs := strings.Split(query, "&")
+		for i, pair := range pairs {
+			rawName, rawValue, found := strings.Cut(pair, "=")
+			if !found {
+				continue
+			}
+			name, err := url.QueryUnescape(rawName)
+			if err != nil {
+				continue
+			}
+			value, err := url.QueryUnescape(rawValue)
+			if err != nil {
+				value = rawValue
+			}
+			if redacted := ua.redactParam(name, value); redacted != value {
+				pairs[i] = rawName + "=" + url.QueryEscape(redacted)
+			}
+		}
+		rawURL = rawURL[:queryStart+1] + strings.Join(pairs, "&") + fragment
+	}
+	return ua.redactValue(rawURL)
+}
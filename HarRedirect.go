@@ -0,0 +1,240 @@
+package main
+
+import (
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+// 不必要的重定向原因
+const (
+	redirectTrailingSlash = "trailing_slash" // 只补上或去掉了末尾的斜杠
+	redirectHTTPSUpgrade  = "https_upgrade"  // 同一地址从http跳转到https（可用HSTS避免）
+)
+
+// 重定向分析结果
+type RedirectAnalysis struct {
+	Redirects       int             `json:"redirects"`       // 重定向响应数
+	Chains          []RedirectChain `json:"chains"`          // 按起始请求组成的重定向链
+	Unnecessary     int             `json:"unnecessary"`     // 不必要的重定向次数
+	UnnecessaryTime float64         `json:"unnecessaryTime"` // 不必要的重定向耗时（毫秒）
+}
+
+// 一条重定向链（3xx响应 → 后续请求 → ...）
+type RedirectChain struct {
+	Hops         []RedirectHop `json:"hops"`         // 每一跳，最后一项为链的终点
+	Redirects    int           `json:"redirects"`    // 重定向次数
+	TotalTime    float64       `json:"totalTime"`    // 整条链的耗时（毫秒）
+	RedirectTime float64       `json:"redirectTime"` // 重定向响应的耗时（毫秒）
+	Downgrades   int           `json:"downgrades"`   // https→http 的次数
+	CrossHost    int           `json:"crossHost"`    // 跨主机的次数
+	Loop         bool          `json:"loop"`         // 重定向回链中已访问过的地址
+	FinalStatus  int           `json:"finalStatus"`
+}
+
+// 重定向链中的一跳
+type RedirectHop struct {
+	URL         string  `json:"url"`
+	Status      int     `json:"status"`
+	Location    string  `json:"location,omitempty"` // 重定向目标（已解析为绝对地址）
+	Time        float64 `json:"time"`               // 请求耗时（毫秒）
+	Downgrade   bool    `json:"downgrade,omitempty"`
+	CrossHost   bool    `json:"crossHost,omitempty"`
+	Unnecessary string  `json:"unnecessary,omitempty"` // 不必要的原因（trailing_slash、https_upgrade）
+}
+
+// 链的起始地址
+func (c RedirectChain) Start() string {
+	return c.Hops[0].URL
+}
+
+// 链的终点地址
+func (c RedirectChain) Final() string {
+	return c.Hops[len(c.Hops)-1].URL
+}
+
+// 链的问题说明（降级、跨主机、循环）
+func (c RedirectChain) Notes() string {
+	var notes []string
+	if c.Downgrades > 0 {
+		notes = append(notes, "⚠️ "+T("report.redirect_downgrade", c.Downgrades))
+	}
+	if c.CrossHost > 0 {
+		notes = append(notes, T("report.redirect_cross_host", c.CrossHost))
+	}
+	if c.Loop {
+		notes = append(notes, "🔁 "+T("report.redirect_loop"))
+	}
+	return strings.Join(notes, ", ")
+}
+
+// 所有不必要的重定向
+func (a *RedirectAnalysis) UnnecessaryHops() []RedirectHop {
+	var hops []RedirectHop
+	for _, chain := range a.Chains {
+		for _, hop := range chain.Hops {
+			if hop.Unnecessary != "" {
+				hops = append(hops, hop)
+			}
+		}
+	}
+	return hops
+}
+
+// 是否为重定向响应（304是缓存再验证，不算重定向）
+func isRedirectStatus(status int) bool {
+	return status >= 300 && status < 400 && status != 304
+}
+
+// 重定向目标的绝对地址（RedirectURL 为空时取 Location 响应头）
+func redirectLocation(entry *HAREntry) string {
+	location := strings.TrimSpace(entry.Response.RedirectURL)
+	if location == "" {
+		location = strings.TrimSpace(headerValue(entry.Response.Headers, "Location"))
+	}
+	if location == "" {
+		return ""
+	}
+	base, err := url.Parse(entry.Request.URL)
+	if err != nil {
+		return location
+	}
+	target, err := base.Parse(location)
+	if err != nil {
+		return location
+	}
+	return target.String()
+}
+
+// 用于匹配请求的地址（规范化协议、主机、端口和路径，忽略片段）
+func redirectURLKey(rawURL string) string {
+	normalized := normalizeURL(rawURL)
+	key := normalized.Scheme + "://" + normalized.Authority() + normalized.Path
+	if parsed, err := url.Parse(rawURL); err == nil && parsed.RawQuery != "" {
+		key += "?" + parsed.RawQuery
+	}
+	return key
+}
+
+// 判断一次重定向是否不必要（目标与原地址只差协议升级或末尾斜杠）
+func unnecessaryRedirect(fromURL, toURL string) string {
+	from, to := normalizeURL(fromURL), normalizeURL(toURL)
+	if from.Host != to.Host || rawQuery(fromURL) != rawQuery(toURL) {
+		return ""
+	}
+	if from.Scheme == "http" && to.Scheme == "https" && from.Path == to.Path {
+		return redirectHTTPSUpgrade
+	}
+	if from.Scheme == to.Scheme && from.Port == to.Port && from.Path != to.Path &&
+		strings.TrimSuffix(from.Path, "/") == strings.TrimSuffix(to.Path, "/") {
+		return redirectTrailingSlash
+	}
+	return ""
+}
+
+// URL的原始查询字符串
+func rawQuery(rawURL string) string {
+	if parsed, err := url.Parse(rawURL); err == nil {
+		return parsed.RawQuery
+	}
+	return ""
+}
+
+// 重建重定向链（没有重定向时返回nil）
+func (ua *UniversalHARAnalyzer) analyzeRedirects(entries []HAREntry) *RedirectAnalysis {
+	// 按开始时间排序，重定向目标只在之后的请求中查找
+	order := make([]int, len(entries))
+	started := make([]time.Time, len(entries))
+	for i := range entries {
+		order[i] = i
+		started[i], _ = time.Parse(time.RFC3339, entries[i].StartedDateTime)
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return started[order[i]].Before(started[order[j]])
+	})
+
+	analysis := &RedirectAnalysis{}
+	next := make(map[int]int)         // 重定向响应 → 跟随的请求
+	locations := make(map[int]string) // 重定向响应 → 目标地址
+	followed := make(map[int]bool)    // 作为重定向目标的请求
+	for position, i := range order {
+		entry := &entries[i]
+		if !isRedirectStatus(entry.Response.Status) {
+			continue
+		}
+		location := redirectLocation(entry)
+		if location == "" {
+			continue
+		}
+		analysis.Redirects++
+		locations[i] = location
+		key := redirectURLKey(location)
+		for _, j := range order[position+1:] {
+			if !followed[j] && redirectURLKey(entries[j].Request.URL) == key {
+				next[i] = j
+				followed[j] = true
+				break
+			}
+		}
+	}
+	if analysis.Redirects == 0 {
+		return nil
+	}
+
+	for _, start := range order {
+		if _, isRedirect := locations[start]; !isRedirect || followed[start] {
+			continue
+		}
+		chain := RedirectChain{}
+		visited := make(map[string]bool)
+		for current, ok := start, true; ok; current, ok = next[current] {
+			entry := &entries[current]
+			visited[redirectURLKey(entry.Request.URL)] = true
+			hop := RedirectHop{
+				URL:    ua.redactURL(entry.Request.URL),
+				Status: entry.Response.Status,
+				Time:   roundMillis(entry.Time),
+			}
+			chain.TotalTime += entry.Time
+			chain.FinalStatus = entry.Response.Status
+
+			if location, isRedirect := locations[current]; isRedirect {
+				hop.Location = ua.redactURL(location)
+				from, to := normalizeURL(entry.Request.URL), normalizeURL(location)
+				hop.Downgrade = from.Scheme == "https" && to.Scheme == "http"
+				hop.CrossHost = from.Authority() != to.Authority()
+				hop.Unnecessary = unnecessaryRedirect(entry.Request.URL, location)
+				chain.Redirects++
+				chain.RedirectTime += entry.Time
+				if hop.Downgrade {
+					chain.Downgrades++
+				}
+				if hop.CrossHost {
+					chain.CrossHost++
+				}
+				if hop.Unnecessary != "" {
+					analysis.Unnecessary++
+					analysis.UnnecessaryTime += entry.Time
+				}
+				if visited[redirectURLKey(location)] {
+					chain.Loop = true
+				}
+			}
+			chain.Hops = append(chain.Hops, hop)
+		}
+		chain.TotalTime = roundMillis(chain.TotalTime)
+		chain.RedirectTime = roundMillis(chain.RedirectTime)
+		analysis.Chains = append(analysis.Chains, chain)
+	}
+	analysis.UnnecessaryTime = roundMillis(analysis.UnnecessaryTime)
+
+	sort.SliceStable(analysis.Chains, func(i, j int) bool {
+		a, b := analysis.Chains[i], analysis.Chains[j]
+		if a.Redirects != b.Redirects {
+			return a.Redirects > b.Redirects
+		}
+		return a.TotalTime > b.TotalTime
+	})
+	return analysis
+}
@@ -0,0 +1,289 @@
+package main
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"regexp"
+	"strings"
+)
+
+// 请求体格式
+const (
+	bodyFormatJSON      = "json"
+	bodyFormatForm      = "form"
+	bodyFormatMultipart = "multipart"
+	bodyFormatXML       = "xml"
+	bodyFormatSOAP      = "soap"
+	bodyFormatText      = "text"
+)
+
+// 没有声明内容类型时识别表单请求体（name=value&name2=value2）
+var formBodyPattern = regexp.MustCompile(`^[^=&\s]+=[^&\s]*(&[^=&\s]+(=[^&\s]*)?)*$`)
+
+// HAR postData.params 中的表单字段
+type HARPostParam struct {
+	Name        string `json:"name"`
+	Value       string `json:"value"`
+	FileName    string `json:"fileName,omitempty"`
+	ContentType string `json:"contentType,omitempty"`
+}
+
+// API请求体中的字段（报告和JSON结果使用）
+type BodyField struct {
+	Name        string `json:"name"`
+	Value       string `json:"value,omitempty"` // 脱敏后的示例值（文件字段为空）
+	FileName    string `json:"fileName,omitempty"`
+	ContentType string `json:"contentType,omitempty"`
+}
+
+// 字段的显示文本（文件字段附带文件名和内容类型）
+func (f BodyField) String() string {
+	switch {
+	case f.FileName != "" && f.ContentType != "":
+		return fmt.Sprintf("%s (%s, %s)", f.Name, f.FileName, f.ContentType)
+	case f.FileName != "":
+		return fmt.Sprintf("%s (%s)", f.Name, f.FileName)
+	}
+	return f.Name
+}
+
+// 带SOAP操作名的路径（/ws (GetUser)）
+func (api APIInfo) DisplayPath() string {
+	if api.Operation == "" {
+		return api.Path
+	}
+	return fmt.Sprintf("%s (%s)", api.Path, api.Operation)
+}
+
+// 请求体字段列表（报告显示用）
+func (api APIInfo) BodyFieldList() string {
+	names := make([]string, len(api.BodyFields))
+	for i, field := range api.BodyFields {
+		names[i] = field.String()
+	}
+	return strings.Join(names, ", ")
+}
+
+// 解析出请求体字段的API（按调用次数排序）
+func (r *UniversalAnalysisResult) RequestBodyAPIs() []APIInfo {
+	var apis []APIInfo
+	for _, api := range r.APIs {
+		if len(api.BodyFields) > 0 {
+			apis = append(apis, api)
+		}
+	}
+	return apis
+}
+
+// 请求体中的一个字段
+type bodyField struct {
+	name        string
+	value       string
+	fileName    string // multipart 文件名
+	contentType string // multipart 部分的内容类型
+}
+
+// 解析后的请求体
+type requestBody struct {
+	format    string
+	fields    []bodyField // 表单、multipart字段或XML元素路径
+	json      interface{} // JSON请求体
+	operation string      // SOAP操作名
+}
+
+// 按内容类型（未声明时按内容）解析请求体
+func parseRequestBody(entry *HAREntry) requestBody {
+	postData := entry.Request.PostData
+	mediaType, mediaParams, _ := mime.ParseMediaType(postData.MimeType)
+	mediaType = strings.ToLower(mediaType)
+	text := postData.Text
+	trimmed := strings.TrimSpace(text)
+
+	switch {
+	case mediaType == "multipart/form-data" || strings.HasPrefix(mediaType, "multipart/"):
+		body := requestBody{format: bodyFormatMultipart, fields: postParamFields(postData.Params)}
+		if len(body.fields) == 0 {
+			body.fields = parseMultipartBody(text, mediaParams["boundary"])
+		}
+		return body
+	case mediaType == "application/x-www-form-urlencoded":
+		body := requestBody{format: bodyFormatForm, fields: postParamFields(postData.Params)}
+		if len(body.fields) == 0 {
+			body.fields = parseFormBody(trimmed)
+		}
+		return body
+	}
+
+	if trimmed == "" {
+		if len(postData.Params) > 0 {
+			return requestBody{format: bodyFormatForm, fields: postParamFields(postData.Params)}
+		}
+		return requestBody{}
+	}
+	if (trimmed[0] == '{' || trimmed[0] == '[') && (mediaType == "" || strings.Contains(mediaType, "json") || strings.HasPrefix(mediaType, "text/")) {
+		if value, err := decodeJSONWithNumbers(trimmed); err == nil {
+			return requestBody{format: bodyFormatJSON, json: value}
+		}
+	}
+	if trimmed[0] == '<' && (mediaType == "" || strings.Contains(mediaType, "xml") || strings.HasPrefix(mediaType, "text/")) {
+		if body, ok := parseXMLBody(trimmed); ok {
+			if body.format == bodyFormatSOAP {
+				if action := soapAction(entry, mediaParams); action != "" {
+					body.operation = action
+				}
+			}
+			return body
+		}
+	}
+	if (mediaType == "" || mediaType == "text/plain") && formBodyPattern.MatchString(trimmed) {
+		return requestBody{format: bodyFormatForm, fields: parseFormBody(trimmed)}
+	}
+	return requestBody{format: bodyFormatText}
+}
+
+// HAR中已解析的表单字段
+func postParamFields(params []HARPostParam) []bodyField {
+	var fields []bodyField
+	for _, param := range params {
+		if param.Name != "" {
+			fields = append(fields, bodyField{name: param.Name, value: param.Value, fileName: param.FileName, contentType: param.ContentType})
+		}
+	}
+	return fields
+}
+
+// 解析URL编码的表单（名称和值都会解码，单个字段也能识别）
+func parseFormBody(text string) []bodyField {
+	var fields []bodyField
+	for _, param := range parseQueryParams(strings.TrimSpace(text)) {
+		fields = append(fields, bodyField{name: param.Name, value: param.Value})
+	}
+	return fields
+}
+
+// 解析multipart请求体（没有boundary参数时从第一行推断；内容被截断时返回已解析的部分）
+func parseMultipartBody(text, boundary string) []bodyField {
+	if boundary == "" {
+		firstLine, _, _ := strings.Cut(strings.TrimLeft(text, "\r\n"), "\n")
+		boundary = strings.TrimPrefix(strings.TrimSpace(firstLine), "--")
+		if boundary == "" {
+			return nil
+		}
+	}
+
+	var fields []bodyField
+	reader := multipart.NewReader(strings.NewReader(text), boundary)
+	for {
+		part, err := reader.NextRawPart()
+		if err != nil {
+			break
+		}
+		field := bodyField{name: part.FormName(), fileName: part.FileName(), contentType: part.Header.Get("Content-Type")}
+		if field.fileName == "" {
+			if value, err := io.ReadAll(io.LimitReader(part, 4096)); err == nil {
+				field.value = string(value)
+			}
+		}
+		if field.name != "" {
+			fields = append(fields, field)
+		}
+	}
+	return fields
+}
+
+// 解析XML请求体，字段为叶子元素和属性的路径（Envelope.Body.GetUser.id、item@type）；SOAP请求去掉 Envelope.Body 前缀
+func parseXMLBody(text string) (requestBody, bool) {
+	body := requestBody{format: bodyFormatXML}
+	decoder := xml.NewDecoder(strings.NewReader(text))
+	decoder.Strict = false
+
+	type element struct {
+		name     string
+		hasChild bool
+		text     strings.Builder
+	}
+	var stack []*element
+	seen := make(map[string]bool)
+	addField := func(path, value string) {
+		if body.format == bodyFormatSOAP {
+			path = strings.TrimPrefix(path, "Envelope.Body.")
+			if path == "Envelope.Body" || strings.HasPrefix(path, "Envelope.") {
+				return
+			}
+		}
+		if !seen[path] {
+			seen[path] = true
+			body.fields = append(body.fields, bodyField{name: path, value: strings.TrimSpace(value)})
+		}
+	}
+	path := func() string {
+		names := make([]string, len(stack))
+		for i, e := range stack {
+			names[i] = e.name
+		}
+		return strings.Join(names, ".")
+	}
+
+	for {
+		token, err := decoder.Token()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			// 只接受能解析出元素的请求体
+			if len(stack) == 0 && len(body.fields) == 0 {
+				return body, false
+			}
+			break
+		}
+		switch t := token.(type) {
+		case xml.StartElement:
+			if len(stack) > 0 {
+				stack[len(stack)-1].hasChild = true
+			}
+			// SOAP：根元素为Envelope，Body下的第一个元素为操作名
+			if len(stack) == 0 && t.Name.Local == "Envelope" {
+				body.format = bodyFormatSOAP
+			}
+			if body.format == bodyFormatSOAP && body.operation == "" && len(stack) == 2 && stack[1].name == "Body" {
+				body.operation = t.Name.Local
+			}
+			stack = append(stack, &element{name: t.Name.Local})
+			for _, attr := range t.Attr {
+				if attr.Name.Space != "xmlns" && attr.Name.Local != "xmlns" {
+					addField(path()+"@"+attr.Name.Local, attr.Value)
+				}
+			}
+		case xml.CharData:
+			if len(stack) > 0 {
+				stack[len(stack)-1].text.Write(t)
+			}
+		case xml.EndElement:
+			if len(stack) == 0 {
+				break
+			}
+			if current := stack[len(stack)-1]; !current.hasChild {
+				addField(path(), current.text.String())
+			}
+			stack = stack[:len(stack)-1]
+		}
+	}
+	return body, len(seen) > 0 || body.operation != ""
+}
+
+// SOAP操作名：SOAPAction 请求头（SOAP 1.1）或内容类型的 action 参数（SOAP 1.2），取最后一段
+func soapAction(entry *HAREntry, mediaParams map[string]string) string {
+	action := headerValue(entry.Request.Headers, "SOAPAction")
+	if action == "" {
+		action = mediaParams["action"]
+	}
+	action = strings.Trim(strings.TrimSpace(action), `"`)
+	if index := strings.LastIndexAny(action, "/#:"); index >= 0 {
+		action = action[index+1:]
+	}
+	return action
+}
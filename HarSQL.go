@@ -0,0 +1,455 @@
+package main
+
+import (
+	"database/sql"
+	"encoding/csv"
+	"flag"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	_ "modernc.org/sqlite"
+)
+
+// HAR数据库表结构
+const harDatabaseSchema = `
+CREATE TABLE IF NOT EXISTS files (
+	id          INTEGER PRIMARY KEY,
+	name        TEXT NOT NULL,
+	path        TEXT NOT NULL,
+	har_version TEXT,
+	creator     TEXT,
+	browser     TEXT
+);
+
+CREATE TABLE IF NOT EXISTS pages (
+	file_id         INTEGER NOT NULL REFERENCES files(id),
+	page_id         TEXT,
+	title           TEXT,
+	started         TEXT,
+	on_content_load REAL,
+	on_load         REAL
+);
+
+CREATE TABLE IF NOT EXISTS entries (
+	id                 INTEGER PRIMARY KEY,
+	file_id            INTEGER NOT NULL REFERENCES files(id),
+	seq                INTEGER NOT NULL,
+	page_ref           TEXT,
+	started            TEXT,
+	started_ms         INTEGER,
+	time_ms            REAL,
+	method             TEXT,
+	url                TEXT,
+	scheme             TEXT,
+	host               TEXT,
+	path               TEXT,
+	path_template      TEXT,
+	query              TEXT,
+	http_version       TEXT,
+	status             INTEGER,
+	status_text        TEXT,
+	mime_type          TEXT,
+	response_type      TEXT,
+	redirect_url       TEXT,
+	request_body_size  REAL,
+	response_body_size REAL,
+	content_size       REAL,
+	request_body       TEXT,
+	response_body      TEXT
+);
+
+CREATE TABLE IF NOT EXISTS headers (
+	entry_id  INTEGER NOT NULL REFERENCES entries(id),
+	direction TEXT NOT NULL,
+	name      TEXT,
+	value     TEXT
+);
+
+CREATE TABLE IF NOT EXISTS query_params (
+	entry_id INTEGER NOT NULL REFERENCES entries(id),
+	name     TEXT,
+	value    TEXT
+);
+
+CREATE TABLE IF NOT EXISTS cookies (
+	entry_id  INTEGER NOT NULL REFERENCES entries(id),
+	direction TEXT NOT NULL,
+	name      TEXT,
+	value     TEXT,
+	domain    TEXT,
+	path      TEXT,
+	expires   TEXT,
+	http_only INTEGER,
+	secure    INTEGER
+);
+
+CREATE TABLE IF NOT EXISTS timings (
+	entry_id INTEGER NOT NULL REFERENCES entries(id),
+	blocked  REAL,
+	dns      REAL,
+	connect  REAL,
+	ssl      REAL,
+	send     REAL,
+	wait     REAL,
+	receive  REAL
+);
+
+CREATE INDEX IF NOT EXISTS idx_entries_file ON entries(file_id);
+CREATE INDEX IF NOT EXISTS idx_headers_entry ON headers(entry_id);
+CREATE INDEX IF NOT EXISTS idx_query_params_entry ON query_params(entry_id);
+CREATE INDEX IF NOT EXISTS idx_cookies_entry ON cookies(entry_id);
+CREATE INDEX IF NOT EXISTS idx_timings_entry ON timings(entry_id);
+`
+
+// 查询结果单元格的最大显示宽度
+const queryCellMaxWidth = 120
+
+// HAR数据库（内嵌纯Go实现的SQLite）
+type HARDatabase struct {
+	db *sql.DB
+}
+
+// 打开HAR数据库，路径为空时使用内存数据库
+func OpenHARDatabase(path string) (*HARDatabase, error) {
+	dsn := path
+	if dsn == "" {
+		dsn = ":memory:"
+	}
+
+	db, err := sql.Open("sqlite", dsn)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", T("err.open_database"), err)
+	}
+	// 内存数据库只在单个连接内可见
+	db.SetMaxOpenConns(1)
+
+	if _, err := db.Exec(harDatabaseSchema); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("%s: %w", T("err.create_tables"), err)
+	}
+
+	return &HARDatabase{db: db}, nil
+}
+
+// 关闭数据库
+func (hd *HARDatabase) Close() error {
+	return hd.db.Close()
+}
+
+// 数据库中记录的HAR文件路径（绝对路径，相对路径和绝对路径指定的同一文件不会重复导入）
+func databaseFilePath(filePath string) string {
+	if abs, err := filepath.Abs(filePath); err == nil {
+		return abs
+	}
+	return filePath
+}
+
+// 删除指定HAR文件已导入的数据（重新导入变化的文件前调用）
+func (hd *HARDatabase) RemoveFile(filePath string) error {
+	filePath = databaseFilePath(filePath)
+	tx, err := hd.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	const fileEntries = `SELECT e.id FROM entries e JOIN files f ON f.id = e.file_id WHERE f.path = ?`
+	for _, statement := range []string{
+		`DELETE FROM headers WHERE entry_id IN (` + fileEntries + `)`,
+		`DELETE FROM query_params WHERE entry_id IN (` + fileEntries + `)`,
+		`DELETE FROM cookies WHERE entry_id IN (` + fileEntries + `)`,
+		`DELETE FROM timings WHERE entry_id IN (` + fileEntries + `)`,
+		`DELETE FROM entries WHERE file_id IN (SELECT id FROM files WHERE path = ?)`,
+		`DELETE FROM pages WHERE file_id IN (SELECT id FROM files WHERE path = ?)`,
+		`DELETE FROM files WHERE path = ?`,
+	} {
+		if _, err := tx.Exec(statement, filePath); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// 导入HAR文件，返回导入的请求数
+func (hd *HARDatabase) ImportHAR(ua *UniversalHARAnalyzer, harFile *UniversalHARFile, filePath string) (int, error) {
+	tx, err := hd.db.Begin()
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	res, err := tx.Exec(`INSERT INTO files (name, path, har_version, creator, browser) VALUES (?, ?, ?, ?, ?)`,
+		filepath.Base(filePath), databaseFilePath(filePath), harFile.Log.Version,
+		strings.TrimSpace(harFile.Log.Creator.Name+" "+harFile.Log.Creator.Version),
+		strings.TrimSpace(harFile.Log.Browser.Name+" "+harFile.Log.Browser.Version))
+	if err != nil {
+		return 0, err
+	}
+	fileID, err := res.LastInsertId()
+	if err != nil {
+		return 0, err
+	}
+
+	for _, page := range harFile.Log.Pages {
+		if _, err := tx.Exec(`INSERT INTO pages (file_id, page_id, title, started, on_content_load, on_load) VALUES (?, ?, ?, ?, ?, ?)`,
+			fileID, page.ID, page.Title, page.StartedDateTime, page.PageTimings.OnContentLoad, page.PageTimings.OnLoad); err != nil {
+			return 0, err
+		}
+	}
+
+	insertEntry, err := tx.Prepare(`INSERT INTO entries (file_id, seq, page_ref, started, started_ms, time_ms, method, url,
+		scheme, host, path, path_template, query, http_version, status, status_text, mime_type, response_type, redirect_url,
+		request_body_size, response_body_size, content_size, request_body, response_body)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`)
+	if err != nil {
+		return 0, err
+	}
+	defer insertEntry.Close()
+
+	insertHeader, err := tx.Prepare(`INSERT INTO headers (entry_id, direction, name, value) VALUES (?, ?, ?, ?)`)
+	if err != nil {
+		return 0, err
+	}
+	defer insertHeader.Close()
+
+	insertParam, err := tx.Prepare(`INSERT INTO query_params (entry_id, name, value) VALUES (?, ?, ?)`)
+	if err != nil {
+		return 0, err
+	}
+	defer insertParam.Close()
+
+	insertCookie, err := tx.Prepare(`INSERT INTO cookies (entry_id, direction, name, value, domain, path, expires, http_only, secure)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`)
+	if err != nil {
+		return 0, err
+	}
+	defer insertCookie.Close()
+
+	insertTiming, err := tx.Prepare(`INSERT INTO timings (entry_id, blocked, dns, connect, ssl, send, wait, receive) VALUES (?, ?, ?, ?, ?, ?, ?, ?)`)
+	if err != nil {
+		return 0, err
+	}
+	defer insertTiming.Close()
+
+	entries := ua.selectEntries(harFile.Log.Entries)
+	for seq, entry := range entries {
+		var startedMs interface{}
+		if started, err := time.Parse(time.RFC3339, entry.StartedDateTime); err == nil {
+			startedMs = started.UnixMilli()
+		}
+
+		requestURL := ua.redactURL(entry.Request.URL)
+		var rawQuery string
+		if parsed, err := url.Parse(requestURL); err == nil {
+			rawQuery = parsed.RawQuery
+		}
+
+		normalized := normalizeURL(entry.Request.URL)
+		res, err := insertEntry.Exec(fileID, seq, entry.Pageref, entry.StartedDateTime, startedMs, entry.Time,
+			entry.Request.Method, requestURL, normalized.Scheme, normalized.Authority(), normalized.Path, ua.templatePath(normalized.Path),
+			rawQuery, entry.Request.HTTPVersion, entry.Response.Status, entry.Response.StatusText,
+			entry.Response.Content.MimeType, ua.simplifyContentType(entry.Response.Content.MimeType), ua.redactURL(entry.Response.RedirectURL),
+			entry.Request.BodySize, entry.Response.BodySize, entry.Response.Content.Size,
+			ua.redactValue(entry.Request.PostData.Text), ua.redactValue(entry.Response.Content.Text))
+		if err != nil {
+			return 0, err
+		}
+		entryID, err := res.LastInsertId()
+		if err != nil {
+			return 0, err
+		}
+
+		for _, header := range entry.Request.Headers {
+			if _, err := insertHeader.Exec(entryID, "request", header.Name, ua.redactHeader(header.Name, header.Value)); err != nil {
+				return 0, err
+			}
+		}
+		for _, header := range entry.Response.Headers {
+			if _, err := insertHeader.Exec(entryID, "response", header.Name, ua.redactHeader(header.Name, header.Value)); err != nil {
+				return 0, err
+			}
+		}
+		for _, param := range mergeQueryParams(entry.Request.QueryString, normalized.Query) {
+			if _, err := insertParam.Exec(entryID, param.Name, ua.redactParam(param.Name, param.Value)); err != nil {
+				return 0, err
+			}
+		}
+		for _, cookie := range entry.Request.Cookies {
+			if _, err := insertCookie.Exec(entryID, "request", cookie.Name, ua.redactCookie(cookie.Name, cookie.Value), cookie.Domain,
+				cookie.Path, cookie.Expires, cookie.HTTPOnly, cookie.Secure); err != nil {
+				return 0, err
+			}
+		}
+		for _, cookie := range entry.Response.Cookies {
+			if _, err := insertCookie.Exec(entryID, "response", cookie.Name, ua.redactCookie(cookie.Name, cookie.Value), cookie.Domain,
+				cookie.Path, cookie.Expires, cookie.HTTPOnly, cookie.Secure); err != nil {
+				return 0, err
+			}
+		}
+
+		t := entry.Timings
+		if _, err := insertTiming.Exec(entryID, t.Blocked, t.DNS, t.Connect, t.SSL, t.Send, t.Wait, t.Receive); err != nil {
+			return 0, err
+		}
+	}
+
+	return len(entries), tx.Commit()
+}
+
+// 执行SQL查询，返回列名和字符串形式的结果行
+func (hd *HARDatabase) Query(query string) ([]string, [][]string, error) {
+	rows, err := hd.db.Query(query)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer rows.Close()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var result [][]string
+	for rows.Next() {
+		values := make([]interface{}, len(columns))
+		pointers := make([]interface{}, len(columns))
+		for i := range values {
+			pointers[i] = &values[i]
+		}
+		if err := rows.Scan(pointers...); err != nil {
+			return nil, nil, err
+		}
+
+		row := make([]string, len(columns))
+		for i, value := range values {
+			switch v := value.(type) {
+			case nil:
+				row[i] = "NULL"
+			case []byte:
+				row[i] = string(v)
+			default:
+				row[i] = fmt.Sprint(v)
+			}
+		}
+		result = append(result, row)
+	}
+
+	return columns, result, rows.Err()
+}
+
+// 以Markdown表格格式输出查询结果
+func writeQueryTable(columns []string, rows [][]string) string {
+	var table strings.Builder
+
+	cell := func(text string) string {
+		text = strings.NewReplacer("\r", " ", "\n", " ", "|", "\\|").Replace(text)
+		if utf8.RuneCountInString(text) > queryCellMaxWidth {
+			text = string([]rune(text)[:queryCellMaxWidth]) + "…"
+		}
+		return text
+	}
+
+	table.WriteString("|")
+	for _, column := range columns {
+		table.WriteString(" " + cell(column) + " |")
+	}
+	table.WriteString("\n|")
+	for range columns {
+		table.WriteString("------|")
+	}
+	table.WriteString("\n")
+
+	for _, row := range rows {
+		table.WriteString("|")
+		for _, value := range row {
+			table.WriteString(" " + cell(value) + " |")
+		}
+		table.WriteString("\n")
+	}
+
+	return table.String()
+}
+
+// SQL查询命令
+func runQueryCommand(args []string) error {
+	analyzer := NewUniversalHARAnalyzer()
+
+	flags := flag.NewFlagSet("query", flag.ExitOnError)
+	dbPath := flags.String("db", "", T("flag.db"))
+	format := flags.String("format", "table", T("flag.query_format"))
+	schema := flags.Bool("schema", false, T("flag.schema"))
+	filter := flags.String("filter", "", T("flag.filter"))
+	excludeStatic := flags.Bool("exclude-static", false, T("flag.exclude_static"))
+	configPath := addConfigFlag(flags)
+	addLanguageFlag(flags)
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), T("query.usage", filepath.Base(os.Args[0])))
+		flags.PrintDefaults()
+	}
+	flags.Parse(args)
+
+	if *schema {
+		fmt.Println(strings.TrimSpace(harDatabaseSchema))
+		return nil
+	}
+	if flags.NArg() == 0 {
+		flags.Usage()
+		return fmt.Errorf("%s", T("query.missing_sql"))
+	}
+	if *format != "table" && *format != "csv" {
+		return fmt.Errorf("%s", T("query.unsupported_format", *format))
+	}
+	if err := analyzer.Configure(flags, *configPath, *filter, *excludeStatic); err != nil {
+		return err
+	}
+
+	database, err := OpenHARDatabase(*dbPath)
+	if err != nil {
+		return err
+	}
+	defer database.Close()
+
+	// 指定数据库且未指定HAR时直接查询已有数据
+	harPaths := flags.Args()[1:]
+	if *dbPath == "" || len(harPaths) > 0 {
+		harFiles, err := analyzer.ResolveHARFiles(harPaths)
+		if err != nil {
+			return err
+		}
+		for _, filePath := range harFiles {
+			harFile, err := analyzer.LoadHARFile(filePath)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "⚠️ %s: %v\n", filepath.Base(filePath), err)
+				continue
+			}
+			// 已导入过的文件先删除旧数据，重复查询不会重复计数
+			if err := database.RemoveFile(filePath); err != nil {
+				return fmt.Errorf("%s: %w", T("query.import_failed", filepath.Base(filePath)), err)
+			}
+			if _, err := database.ImportHAR(analyzer, harFile, filePath); err != nil {
+				return fmt.Errorf("%s: %w", T("query.import_failed", filepath.Base(filePath)), err)
+			}
+		}
+	}
+
+	columns, rows, err := database.Query(flags.Arg(0))
+	if err != nil {
+		return err
+	}
+
+	if *format == "csv" {
+		writer := csv.NewWriter(os.Stdout)
+		writer.Write(columns)
+		writer.WriteAll(rows)
+		return writer.Error()
+	}
+
+	fmt.Print(writeQueryTable(columns, rows))
+	fmt.Fprintln(os.Stderr, T("query.row_count", len(rows)))
+	return nil
+}
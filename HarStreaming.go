@@ -0,0 +1,309 @@
+package main
+
+import (
+	"encoding/json"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// 流式响应的格式
+const (
+	streamFormatSSE    = "sse"
+	streamFormatNDJSON = "ndjson"
+)
+
+// NDJSON流的内容类型关键字
+var ndjsonContentTypes = []string{"ndjson", "jsonl", "json-seq", "stream+json", "x-json-stream"}
+
+// 流式响应分析结果
+type StreamAnalysis struct {
+	Streams    []EventStream     `json:"streams"`           // 每个流式响应的统计
+	EventTypes []StreamEventType `json:"eventTypes"`        // 所有流合并的事件类型
+	GoTypes    []string          `json:"goTypes,omitempty"` // 事件负载的Go结构体
+}
+
+// 单个流式响应（SSE或NDJSON）的统计
+type EventStream struct {
+	URL             string            `json:"url"`
+	Method          string            `json:"method"`
+	Host            string            `json:"host"`
+	Path            string            `json:"path"`
+	Format          string            `json:"format"` // sse 或 ndjson
+	Status          int               `json:"status"`
+	Events          int               `json:"events"`
+	Bytes           int               `json:"bytes"`
+	Comments        int               `json:"comments,omitempty"` // SSE注释行（常用作心跳）
+	FirstByte       float64           `json:"firstByte"`          // 首字节时间（毫秒）
+	Duration        float64           `json:"duration"`           // 接收时长（毫秒）
+	EventsPerSecond float64           `json:"eventsPerSecond"`
+	LastEventID     string            `json:"lastEventId,omitempty"`
+	Retry           int               `json:"retry,omitempty"` // 服务器指定的重连间隔（毫秒）
+	Done            bool              `json:"done,omitempty"`  // 以 [DONE] 结束
+	EventTypes      []StreamEventType `json:"eventTypes"`
+}
+
+// 事件类型（SSE按事件名，NDJSON按区分字段）
+type StreamEventType struct {
+	Name   string `json:"name"`
+	Format string `json:"format"` // json 或 text
+	Count  int    `json:"count"`
+	Bytes  int    `json:"bytes"`
+	GoType string `json:"goType,omitempty"` // 生成的Go结构体名
+
+	payload *jsonShape
+}
+
+// 解析出的单个SSE事件
+type sseEvent struct {
+	name  string
+	id    string
+	data  string
+	retry int
+}
+
+// 分析所有流式响应（没有流式响应时返回nil）
+func (ua *UniversalHARAnalyzer) analyzeStreams(entries []HAREntry) *StreamAnalysis {
+	analysis := &StreamAnalysis{}
+	allTypes := make(map[string]*StreamEventType)
+	var typeOrder []string
+
+	for i := range entries {
+		entry := &entries[i]
+		body := responseBodyText(entry)
+		format := streamFormat(entry, body)
+		if format == "" {
+			continue
+		}
+
+		normalized := normalizeURL(entry.Request.URL)
+		stream := EventStream{
+			URL:       ua.redactURL(entry.Request.URL),
+			Method:    entry.Request.Method,
+			Host:      normalized.Authority(),
+			Path:      normalized.Path,
+			Format:    format,
+			Status:    entry.Response.Status,
+			Bytes:     len(body),
+			FirstByte: roundMillis(math.Max(entry.Timings.Wait, 0)),
+			Duration:  roundMillis(math.Max(entry.Timings.Receive, 0)),
+		}
+		streamTypes := make(map[string]*StreamEventType)
+		var streamOrder []string
+
+		// 同时计入本流和全局的事件类型
+		addEvent := func(name, data string) {
+			value, isJSON := interface{}(nil), false
+			if json.Valid([]byte(data)) {
+				if decoded, err := decodeJSONWithNumbers(data); err == nil {
+					value, isJSON = decoded, true
+				}
+			}
+			if object, ok := value.(map[string]interface{}); ok && name == "" {
+				if field, discriminator, found := messageDiscriminator(object); found {
+					name = field + "=" + discriminator
+				}
+			}
+			eventFormat := "text"
+			if isJSON {
+				eventFormat = "json"
+			}
+			if name == "" && format == streamFormatSSE {
+				name = "message"
+			} else if name == "" {
+				name = eventFormat
+			}
+
+			stream.Events++
+			for _, types := range []struct {
+				byKey map[string]*StreamEventType
+				order *[]string
+			}{{streamTypes, &streamOrder}, {allTypes, &typeOrder}} {
+				key := format + "\x00" + name + "\x00" + eventFormat
+				eventType, exists := types.byKey[key]
+				if !exists {
+					eventType = &StreamEventType{Name: name, Format: eventFormat, payload: newJSONShape()}
+					types.byKey[key] = eventType
+					*types.order = append(*types.order, key)
+				}
+				eventType.Count++
+				eventType.Bytes += len(data)
+				if isJSON {
+					eventType.payload.add(value)
+				}
+			}
+		}
+
+		switch format {
+		case streamFormatSSE:
+			events, comments := parseSSE(body)
+			stream.Comments = comments
+			for _, event := range events {
+				if event.id != "" {
+					stream.LastEventID = event.id
+				}
+				if event.retry > 0 {
+					stream.Retry = event.retry
+				}
+				// OpenAI风格的结束标记
+				if strings.TrimSpace(event.data) == "[DONE]" {
+					stream.Done = true
+					continue
+				}
+				addEvent(event.name, event.data)
+			}
+		case streamFormatNDJSON:
+			for _, line := range ndjsonLines(body) {
+				addEvent("", line)
+			}
+		}
+
+		if stream.Duration > 0 {
+			stream.EventsPerSecond = math.Round(float64(stream.Events)/stream.Duration*1000*100) / 100
+		}
+		stream.EventTypes = sortedStreamEventTypes(streamTypes, streamOrder)
+		analysis.Streams = append(analysis.Streams, stream)
+	}
+	if len(analysis.Streams) == 0 {
+		return nil
+	}
+
+	// 为JSON事件负载生成Go结构体
+	generator := newGoTypeGenerator()
+	goTypes := make(map[string]string)
+	for _, key := range typeOrder {
+		eventType := allTypes[key]
+		if eventType.payload.kind() != "object" || len(eventType.payload.fields) == 0 {
+			continue
+		}
+		name := eventType.Name
+		if name == "json" {
+			name = "stream"
+		}
+		if index := strings.Index(name, "="); index >= 0 {
+			name = name[index+1:]
+		}
+		name = goTypeName(name)
+		if !strings.HasSuffix(name, "Event") {
+			name += "Event"
+		}
+		eventType.GoType = strings.TrimPrefix(generator.generate(name, eventType.payload), "*")
+		goTypes[key] = eventType.GoType
+	}
+	analysis.GoTypes = generator.code()
+	analysis.EventTypes = sortedStreamEventTypes(allTypes, typeOrder)
+	for i := range analysis.Streams {
+		for j := range analysis.Streams[i].EventTypes {
+			eventType := &analysis.Streams[i].EventTypes[j]
+			eventType.GoType = goTypes[analysis.Streams[i].Format+"\x00"+eventType.Name+"\x00"+eventType.Format]
+		}
+	}
+	return analysis
+}
+
+// 按事件数降序排列事件类型
+func sortedStreamEventTypes(types map[string]*StreamEventType, order []string) []StreamEventType {
+	sorted := make([]StreamEventType, 0, len(order))
+	for _, key := range order {
+		sorted = append(sorted, *types[key])
+	}
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Count > sorted[j].Count
+	})
+	return sorted
+}
+
+// 判断响应是否为流式响应，返回格式（不是时返回空字符串）
+func streamFormat(entry *HAREntry, body string) string {
+	mimeType := strings.ToLower(entry.Response.Content.MimeType)
+	if mimeType == "" {
+		mimeType = strings.ToLower(headerValue(entry.Response.Headers, "Content-Type"))
+	}
+	if strings.Contains(mimeType, "event-stream") {
+		return streamFormatSSE
+	}
+	for _, keyword := range ndjsonContentTypes {
+		if strings.Contains(mimeType, keyword) {
+			return streamFormatNDJSON
+		}
+	}
+	// 以 application/json 返回、逐行输出JSON的分块响应
+	if strings.Contains(mimeType, "json") && !json.Valid([]byte(body)) {
+		if lines := ndjsonLines(body); len(lines) >= 2 {
+			for _, line := range lines {
+				if !json.Valid([]byte(line)) {
+					return ""
+				}
+			}
+			return streamFormatNDJSON
+		}
+	}
+	return ""
+}
+
+// NDJSON流中的非空行（去掉JSON文本序列的RS前缀）
+func ndjsonLines(body string) []string {
+	var lines []string
+	for _, line := range strings.Split(body, "\n") {
+		line = strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(line), "\x1e"))
+		if line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
+
+// 按SSE规范解析事件流，返回事件和注释行数
+func parseSSE(body string) ([]sseEvent, int) {
+	var events []sseEvent
+	comments := 0
+	var current sseEvent
+	var data []string
+	hasData := false
+
+	dispatch := func() {
+		if hasData {
+			current.data = strings.Join(data, "\n")
+			events = append(events, current)
+		}
+		current = sseEvent{}
+		data = nil
+		hasData = false
+	}
+
+	body = strings.TrimPrefix(body, "\uFEFF")
+	body = strings.ReplaceAll(body, "\r\n", "\n")
+	body = strings.ReplaceAll(body, "\r", "\n")
+	for _, line := range strings.Split(body, "\n") {
+		if line == "" {
+			dispatch()
+			continue
+		}
+		if strings.HasPrefix(line, ":") {
+			comments++
+			continue
+		}
+
+		field, value, _ := strings.Cut(line, ":")
+		value = strings.TrimPrefix(value, " ")
+		switch field {
+		case "event":
+			current.name = value
+		case "data":
+			data = append(data, value)
+			hasData = true
+		case "id":
+			if !strings.Contains(value, "\x00") {
+				current.id = value
+			}
+		case "retry":
+			if retry, err := strconv.Atoi(value); err == nil && retry >= 0 {
+				current.retry = retry
+			}
+		}
+	}
+	// 抓包时流可能被截断，最后一个未结束的事件也计入
+	dispatch()
+	return events, comments
+}
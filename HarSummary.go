@@ -0,0 +1,209 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// 汇总报告文件名（summary_report.md、summary_report.json，非覆盖模式下追加时间戳或版本号；HAR文件不会使用该基础名）
+const (
+	summaryBaseName = "summary"
+	summaryKind     = "report"
+)
+
+// 跨文件汇总结果
+type SummaryResult struct {
+	GeneratedAt   time.Time         `json:"generatedAt"`
+	TotalRequests int               `json:"totalRequests"`
+	Files         []SummaryFile     `json:"files"`
+	Hosts         []SummaryHost     `json:"hosts"`
+	Endpoints     []SummaryEndpoint `json:"endpoints"`
+	StatusCodes   map[string]int    `json:"statusCodes"` // 所有文件合并的状态码统计
+	Methods       map[string]int    `json:"methods"`     // 所有文件合并的HTTP方法统计
+	Latency       LatencyStats      `json:"latency"`     // 所有请求的耗时统计（毫秒）
+}
+
+// 单个文件的对比数据
+type SummaryFile struct {
+	Label         string       `json:"label"` // 在主机/端点表中使用的简称（F1、F2...）
+	FileName      string       `json:"fileName"`
+	FilePath      string       `json:"filePath,omitempty"`
+	RelativePath  string       `json:"relativePath,omitempty"`
+	TotalRequests int          `json:"totalRequests"`
+	UniqueHosts   int          `json:"uniqueHosts"`
+	APICount      int          `json:"apiCount"`
+	Errors        int          `json:"errors"`
+	ErrorRate     float64      `json:"errorRate"` // 百分比
+	TimeSpan      string       `json:"timeSpan"`
+	Latency       LatencyStats `json:"latency"`
+}
+
+// 全局主机统计
+type SummaryHost struct {
+	Host          string `json:"host"`
+	TotalRequests int    `json:"totalRequests"`
+	FileCounts    []int  `json:"fileCounts"` // 按 Files 顺序的各文件请求数
+}
+
+// 全局端点统计
+type SummaryEndpoint struct {
+	Method     string       `json:"method"`
+	Host       string       `json:"host"`
+	Path       string       `json:"path"`
+	Operation  string       `json:"operation,omitempty"` // SOAP操作名
+	TotalCalls int          `json:"totalCalls"`
+	Errors     int          `json:"errors"`
+	FileCounts []int        `json:"fileCounts"` // 按 Files 顺序的各文件调用次数
+	SeenIn     []string     `json:"seenIn"`     // 出现该端点的文件简称
+	MissingIn  []string     `json:"missingIn"`  // 未出现该端点的文件简称
+	Latency    LatencyStats `json:"latency"`
+}
+
+// 合并所有文件的分析结果
+func BuildSummary(results []*UniversalAnalysisResult) *SummaryResult {
+	summary := &SummaryResult{
+		GeneratedAt: time.Now(),
+		StatusCodes: make(map[string]int),
+		Methods:     make(map[string]int),
+	}
+
+	hostMap := make(map[string]*SummaryHost)
+	endpointMap := make(map[string]*SummaryEndpoint)
+	endpointDurations := make(map[string][]float64)
+	var durations []float64
+
+	for i, result := range results {
+		file := SummaryFile{
+			Label:         "F" + strconv.Itoa(i+1),
+			FileName:      result.Metadata.FileName,
+			FilePath:      result.Metadata.FilePath,
+			RelativePath:  result.Metadata.RelativePath,
+			TotalRequests: result.Metadata.TotalRequests,
+			UniqueHosts:   result.Metadata.UniqueHosts,
+			APICount:      len(result.APIs),
+			TimeSpan:      result.Metadata.TimeSpan,
+			Latency:       result.Latency,
+		}
+		for code, count := range result.ExtractedData.StatusCodes {
+			summary.StatusCodes[code] += count
+			if status, err := strconv.Atoi(code); err == nil && (status == 0 || status >= 400) {
+				file.Errors += count
+			}
+		}
+		if file.TotalRequests > 0 {
+			file.ErrorRate = roundMillis(float64(file.Errors) * 100 / float64(file.TotalRequests))
+		}
+		for method, count := range result.ExtractedData.Methods {
+			summary.Methods[method] += count
+		}
+		summary.Files = append(summary.Files, file)
+		summary.TotalRequests += result.Metadata.TotalRequests
+
+		for _, host := range result.Hosts {
+			if _, exists := hostMap[host.Host]; !exists {
+				hostMap[host.Host] = &SummaryHost{Host: host.Host, FileCounts: make([]int, len(results))}
+			}
+			hostMap[host.Host].TotalRequests += host.RequestCount
+			hostMap[host.Host].FileCounts[i] += host.RequestCount
+		}
+
+		for _, api := range result.APIs {
+			key := fmt.Sprintf("%s %s%s %s", api.Method, api.Host, api.Path, api.Operation)
+			if _, exists := endpointMap[key]; !exists {
+				endpointMap[key] = &SummaryEndpoint{
+					Method:     api.Method,
+					Host:       api.Host,
+					Path:       api.Path,
+					Operation:  api.Operation,
+					FileCounts: make([]int, len(results)),
+				}
+			}
+			endpointMap[key].TotalCalls += api.CallCount
+			endpointMap[key].Errors += api.ErrorCount
+			endpointMap[key].FileCounts[i] += api.CallCount
+			endpointDurations[key] = append(endpointDurations[key], api.Durations...)
+			durations = append(durations, api.Durations...)
+		}
+	}
+
+	for _, host := range hostMap {
+		summary.Hosts = append(summary.Hosts, *host)
+	}
+	sort.Slice(summary.Hosts, func(i, j int) bool {
+		if summary.Hosts[i].TotalRequests != summary.Hosts[j].TotalRequests {
+			return summary.Hosts[i].TotalRequests > summary.Hosts[j].TotalRequests
+		}
+		return summary.Hosts[i].Host < summary.Hosts[j].Host
+	})
+
+	for key, endpoint := range endpointMap {
+		endpoint.SeenIn = []string{}
+		endpoint.MissingIn = []string{}
+		for i, count := range endpoint.FileCounts {
+			if count > 0 {
+				endpoint.SeenIn = append(endpoint.SeenIn, summary.Files[i].Label)
+			} else {
+				endpoint.MissingIn = append(endpoint.MissingIn, summary.Files[i].Label)
+			}
+		}
+		endpoint.Latency = computeLatencyStats(endpointDurations[key])
+		summary.Endpoints = append(summary.Endpoints, *endpoint)
+	}
+	sort.Slice(summary.Endpoints, func(i, j int) bool {
+		a, b := summary.Endpoints[i], summary.Endpoints[j]
+		if a.TotalCalls != b.TotalCalls {
+			return a.TotalCalls > b.TotalCalls
+		}
+		if a.Host != b.Host {
+			return a.Host < b.Host
+		}
+		if a.Path != b.Path {
+			return a.Path < b.Path
+		}
+		if a.Method != b.Method {
+			return a.Method < b.Method
+		}
+		return a.Operation < b.Operation
+	})
+
+	summary.Latency = computeLatencyStats(durations)
+	return summary
+}
+
+// 仅在部分文件中出现的端点
+func (s *SummaryResult) PartialEndpoints() []SummaryEndpoint {
+	var partial []SummaryEndpoint
+	for _, endpoint := range s.Endpoints {
+		if len(endpoint.MissingIn) > 0 {
+			partial = append(partial, endpoint)
+		}
+	}
+	return partial
+}
+
+// 生成汇总报告（Markdown和JSON）
+func (ua *UniversalHARAnalyzer) generateSummaryReport(results []*UniversalAnalysisResult) error {
+	summary := BuildSummary(results)
+
+	jsonData, err := json.MarshalIndent(summary, "", "  ")
+	if err != nil {
+		return err
+	}
+	jsonFile := ua.artifactPath(summaryBaseName, summaryKind, ".json")
+	if err := os.WriteFile(jsonFile, jsonData, 0644); err != nil {
+		return err
+	}
+	if err := ua.recordArtifact(jsonFile, "summary", ""); err != nil {
+		return err
+	}
+
+	reportFile := ua.artifactPath(summaryBaseName, summaryKind, ".md")
+	if err := ua.writeBuiltinTemplate("summary.md.tmpl", reportFile, summary); err != nil {
+		return err
+	}
+	return ua.recordArtifact(reportFile, "summary", "")
+}
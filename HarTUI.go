@@ -0,0 +1,634 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/mattn/go-runewidth"
+)
+
+// 终端界面视图
+const (
+	tuiViewEntries = iota
+	tuiViewAPIs
+)
+
+// 请求列表的排序方式（0 为原始顺序）
+var tuiSortColumns = []string{"#", "method", "status", "host", "path", "time", "size"}
+
+// 终端界面中的请求
+type tuiEntry struct {
+	file      string
+	index     int
+	entry     *HAREntry
+	host      string
+	path      string
+	operation string // SOAP操作名，用于API跳转
+	text      string // 用于文本过滤的小写内容
+}
+
+// 终端界面状态
+type tuiApp struct {
+	analyzer  *UniversalHARAnalyzer
+	screen    tcell.Screen
+	files     []string
+	entries   []tuiEntry
+	endpoints []SummaryEndpoint
+
+	view    int
+	visible []int // 经过过滤和排序后显示的请求
+	cursor  int
+	offset  int
+
+	apiCursor int
+	apiOffset int
+	api       *SummaryEndpoint // 从API表跳转时只显示该API的调用
+
+	filter     string
+	expression *EntryFilter // 过滤文本能解析为过滤表达式时使用表达式
+	editing    bool
+
+	sortColumn int
+	sortDesc   bool
+
+	detailFocus  bool
+	detailOffset int
+}
+
+// 运行 tui 子命令
+func runTUICommand(args []string) error {
+	analyzer := NewUniversalHARAnalyzer()
+
+	flags := flag.NewFlagSet("tui", flag.ExitOnError)
+	filter := flags.String("filter", "", T("flag.filter"))
+	excludeStatic := flags.Bool("exclude-static", false, T("flag.exclude_static"))
+	configPath := addConfigFlag(flags)
+	addLanguageFlag(flags)
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), T("tui.usage", filepath.Base(os.Args[0])))
+		flags.PrintDefaults()
+	}
+	flags.Parse(args)
+
+	if err := analyzer.Configure(flags, *configPath, *filter, *excludeStatic); err != nil {
+		return err
+	}
+	harFiles, err := analyzer.ResolveHARFiles(flags.Args())
+	if err != nil {
+		return err
+	}
+	if len(harFiles) == 0 {
+		return fmt.Errorf("%s", T("common.no_har_files"))
+	}
+
+	app := &tuiApp{analyzer: analyzer}
+	if err := app.load(harFiles); err != nil {
+		return err
+	}
+
+	screen, err := tcell.NewScreen()
+	if err != nil {
+		return err
+	}
+	if err := screen.Init(); err != nil {
+		return err
+	}
+	defer screen.Fini()
+	app.screen = screen
+	app.run()
+	return nil
+}
+
+// 加载HAR文件并生成API表
+func (app *tuiApp) load(harFiles []string) error {
+	var results []*UniversalAnalysisResult
+	for _, filePath := range harFiles {
+		harFile, err := app.analyzer.LoadHARFile(filePath)
+		if err != nil {
+			return fmt.Errorf("%s: %w", filePath, err)
+		}
+		name := filepath.Base(filePath)
+		app.files = append(app.files, name)
+
+		entries := app.analyzer.selectEntries(harFile.Log.Entries)
+		for i := range entries {
+			entry := &entries[i]
+			item := tuiEntry{
+				file:      name,
+				index:     len(app.entries),
+				entry:     entry,
+				host:      app.analyzer.extractHost(entry.Request.URL),
+				path:      app.analyzer.extractPath(entry.Request.URL),
+				operation: parseRequestBody(entry).operation,
+			}
+			item.text = strings.ToLower(fmt.Sprintf("%s %d %s %s %s", entry.Request.Method, entry.Response.Status, item.host, item.path, name))
+			app.entries = append(app.entries, item)
+		}
+		results = append(results, app.analyzer.AnalyzeHAR(harFile, filePath))
+	}
+	app.endpoints = BuildSummary(results).Endpoints
+	app.refresh()
+	return nil
+}
+
+// 重新计算显示的请求（过滤、API跳转和排序）
+func (app *tuiApp) refresh() {
+	app.visible = app.visible[:0]
+	for i := range app.entries {
+		item := &app.entries[i]
+		if app.api != nil && (item.entry.Request.Method != app.api.Method || item.host != app.api.Host || item.path != app.api.Path || item.operation != app.api.Operation) {
+			continue
+		}
+		if app.expression != nil {
+			if !app.expression.Match(item.entry) {
+				continue
+			}
+		} else if app.filter != "" && !strings.Contains(item.text, strings.ToLower(app.filter)) {
+			continue
+		}
+		app.visible = append(app.visible, i)
+	}
+
+	if app.sortColumn > 0 {
+		sort.SliceStable(app.visible, func(i, j int) bool {
+			a, b := &app.entries[app.visible[i]], &app.entries[app.visible[j]]
+			if app.sortDesc {
+				a, b = b, a
+			}
+			switch tuiSortColumns[app.sortColumn] {
+			case "method":
+				return a.entry.Request.Method < b.entry.Request.Method
+			case "status":
+				return a.entry.Response.Status < b.entry.Response.Status
+			case "host":
+				return a.host < b.host
+			case "path":
+				return a.path < b.path
+			case "time":
+				return a.entry.Time < b.entry.Time
+			case "size":
+				return a.entry.Response.Content.Size < b.entry.Response.Content.Size
+			}
+			return false
+		})
+	} else if app.sortDesc {
+		for i, j := 0, len(app.visible)-1; i < j; i, j = i+1, j-1 {
+			app.visible[i], app.visible[j] = app.visible[j], app.visible[i]
+		}
+	}
+
+	if app.cursor >= len(app.visible) {
+		app.cursor = len(app.visible) - 1
+	}
+	if app.cursor < 0 {
+		app.cursor = 0
+	}
+	app.detailOffset = 0
+}
+
+// 设置过滤文本（能解析为过滤表达式时按表达式过滤，否则按文本匹配）
+func (app *tuiApp) setFilter(text string) {
+	app.filter = text
+	app.expression = nil
+	if strings.TrimSpace(text) != "" {
+		if expression, err := parseEntryFilter(text, app.analyzer); err == nil {
+			app.expression = expression
+		}
+	}
+	app.cursor = 0
+	app.refresh()
+}
+
+// 事件循环
+func (app *tuiApp) run() {
+	for {
+		app.draw()
+		switch event := app.screen.PollEvent().(type) {
+		case *tcell.EventResize:
+			app.screen.Sync()
+		case *tcell.EventKey:
+			if !app.handleKey(event) {
+				return
+			}
+		}
+	}
+}
+
+// 处理按键，返回false表示退出
+func (app *tuiApp) handleKey(event *tcell.EventKey) bool {
+	if event.Key() == tcell.KeyCtrlC {
+		return false
+	}
+
+	// 编辑过滤条件
+	if app.editing {
+		switch event.Key() {
+		case tcell.KeyEnter:
+			app.editing = false
+		case tcell.KeyEscape:
+			app.editing = false
+			app.setFilter("")
+		case tcell.KeyBackspace, tcell.KeyBackspace2:
+			if runes := []rune(app.filter); len(runes) > 0 {
+				app.setFilter(string(runes[:len(runes)-1]))
+			}
+		case tcell.KeyRune:
+			app.setFilter(app.filter + string(event.Rune()))
+		}
+		return true
+	}
+
+	if app.view == tuiViewAPIs {
+		return app.handleAPIKey(event)
+	}
+
+	_, height := app.screen.Size()
+	page := app.listHeight(height)
+	switch event.Key() {
+	case tcell.KeyUp:
+		app.move(-1)
+	case tcell.KeyDown:
+		app.move(1)
+	case tcell.KeyPgUp:
+		app.move(-page)
+	case tcell.KeyPgDn:
+		app.move(page)
+	case tcell.KeyHome:
+		app.move(-len(app.entries))
+	case tcell.KeyEnd:
+		app.move(len(app.entries))
+	case tcell.KeyTab, tcell.KeyEnter:
+		app.detailFocus = !app.detailFocus
+	case tcell.KeyEscape:
+		switch {
+		case app.detailFocus:
+			app.detailFocus = false
+		case app.api != nil:
+			app.api = nil
+			app.refresh()
+		case app.filter != "":
+			app.setFilter("")
+		}
+	case tcell.KeyRune:
+		switch event.Rune() {
+		case 'q':
+			return false
+		case 'k':
+			app.move(-1)
+		case 'j':
+			app.move(1)
+		case 'g':
+			app.move(-len(app.entries))
+		case 'G':
+			app.move(len(app.entries))
+		case '/':
+			app.editing = true
+			app.detailFocus = false
+		case 's':
+			app.sortColumn = (app.sortColumn + 1) % len(tuiSortColumns)
+			app.refresh()
+		case 'r':
+			app.sortDesc = !app.sortDesc
+			app.refresh()
+		case 'a':
+			app.view = tuiViewAPIs
+			app.detailFocus = false
+		}
+	}
+	return true
+}
+
+// 处理API表中的按键
+func (app *tuiApp) handleAPIKey(event *tcell.EventKey) bool {
+	_, height := app.screen.Size()
+	page := height - 3
+	moveAPI := func(delta int) {
+		app.apiCursor = clampInt(app.apiCursor+delta, 0, len(app.endpoints)-1)
+	}
+	switch event.Key() {
+	case tcell.KeyUp:
+		moveAPI(-1)
+	case tcell.KeyDown:
+		moveAPI(1)
+	case tcell.KeyPgUp:
+		moveAPI(-page)
+	case tcell.KeyPgDn:
+		moveAPI(page)
+	case tcell.KeyHome:
+		moveAPI(-len(app.endpoints))
+	case tcell.KeyEnd:
+		moveAPI(len(app.endpoints))
+	case tcell.KeyEnter:
+		// 跳转到该API的所有调用
+		if len(app.endpoints) > 0 {
+			app.api = &app.endpoints[app.apiCursor]
+			app.view = tuiViewEntries
+			app.cursor = 0
+			app.refresh()
+		}
+	case tcell.KeyEscape:
+		app.view = tuiViewEntries
+	case tcell.KeyRune:
+		switch event.Rune() {
+		case 'q':
+			return false
+		case 'k':
+			moveAPI(-1)
+		case 'j':
+			moveAPI(1)
+		case 'a', 'e':
+			app.view = tuiViewEntries
+		}
+	}
+	return true
+}
+
+// 移动光标（焦点在详情区时滚动详情）
+func (app *tuiApp) move(delta int) {
+	if app.detailFocus {
+		app.detailOffset = maxInt(app.detailOffset+delta, 0)
+		return
+	}
+	app.cursor = clampInt(app.cursor+delta, 0, len(app.visible)-1)
+	app.detailOffset = 0
+}
+
+// 请求列表的高度（屏幕上半部分）
+func (app *tuiApp) listHeight(height int) int {
+	return maxInt((height-3)/2, 3)
+}
+
+// 绘制界面
+func (app *tuiApp) draw() {
+	screen := app.screen
+	screen.Clear()
+	width, height := screen.Size()
+
+	title := T("tui.title", strings.Join(app.files, ", "), len(app.visible), len(app.entries))
+	if app.api != nil {
+		title += "  " + T("tui.api_filter", app.api.Method, app.api.Host+endpointDisplayPath(*app.api))
+	}
+	drawTUIText(screen, 0, 0, width, tcell.StyleDefault.Reverse(true), padRight(title, width))
+
+	if app.view == tuiViewAPIs {
+		app.drawAPIs(width, height)
+	} else {
+		app.drawEntries(width, height)
+	}
+
+	// 底部状态行
+	status := T("tui.help_entries")
+	switch {
+	case app.editing:
+		status = T("tui.filter_editing", app.filter)
+	case app.view == tuiViewAPIs:
+		status = T("tui.help_apis")
+	case app.filter != "":
+		kind := T("tui.filter_text")
+		if app.expression != nil {
+			kind = T("tui.filter_expression")
+		}
+		status = T("tui.filter_status", kind, app.filter) + "  " + status
+	}
+	if app.view == tuiViewEntries && !app.editing {
+		order := "▲"
+		if app.sortDesc {
+			order = "▼"
+		}
+		status = T("tui.sort_status", tuiSortColumns[app.sortColumn], order) + "  " + status
+	}
+	drawTUIText(screen, 0, height-1, width, tcell.StyleDefault.Reverse(true), padRight(status, width))
+	screen.Show()
+}
+
+// 绘制请求列表和详情
+func (app *tuiApp) drawEntries(width, height int) {
+	listHeight := app.listHeight(height)
+	columns := []struct {
+		title string
+		width int
+	}{
+		{T("col.method"), 7},
+		{T("col.status"), 6},
+		{T("col.host"), 24},
+		{T("col.path"), 0},
+		{T("col.time"), 9},
+		{T("col.size"), 9},
+	}
+	if len(app.files) > 1 {
+		columns = append(columns, struct {
+			title string
+			width int
+		}{T("col.file"), 16})
+	}
+	fixed := 0
+	for _, column := range columns {
+		fixed += column.width + 1
+	}
+	columns[3].width = maxInt(width-fixed, 10)
+
+	header := make([]string, len(columns))
+	for i, column := range columns {
+		header[i] = fitText(column.title, column.width)
+	}
+	drawTUIText(app.screen, 0, 1, width, tcell.StyleDefault.Bold(true), strings.Join(header, " "))
+
+	if len(app.visible) == 0 {
+		drawTUIText(app.screen, 0, 2, width, tcell.StyleDefault, T("tui.no_entries"))
+	}
+	if app.cursor < app.offset {
+		app.offset = app.cursor
+	}
+	if app.cursor >= app.offset+listHeight {
+		app.offset = app.cursor - listHeight + 1
+	}
+	for row := 0; row < listHeight && app.offset+row < len(app.visible); row++ {
+		item := &app.entries[app.visible[app.offset+row]]
+		entry := item.entry
+		cells := []string{
+			entry.Request.Method,
+			strconv.Itoa(entry.Response.Status),
+			item.host,
+			item.path,
+			formatDurationMillis(entry.Time),
+			formatByteSize(entry.Response.Content.Size),
+			item.file,
+		}
+		line := make([]string, len(columns))
+		for i, column := range columns {
+			line[i] = fitText(cells[i], column.width)
+		}
+
+		style := tcell.StyleDefault
+		if entry.Response.Status == 0 || entry.Response.Status >= 400 {
+			style = style.Foreground(tcell.ColorRed)
+		}
+		if app.offset+row == app.cursor {
+			style = style.Reverse(true)
+			if app.detailFocus {
+				style = style.Dim(true)
+			}
+		}
+		drawTUIText(app.screen, 0, 2+row, width, style, padRight(strings.Join(line, " "), width))
+	}
+
+	// 详情区
+	top := 2 + listHeight
+	separatorStyle := tcell.StyleDefault.Foreground(tcell.ColorGray)
+	if app.detailFocus {
+		separatorStyle = tcell.StyleDefault.Foreground(tcell.ColorYellow)
+	}
+	for x := 0; x < width; x++ {
+		app.screen.SetContent(x, top, tcell.RuneHLine, nil, separatorStyle)
+	}
+	if len(app.visible) == 0 {
+		return
+	}
+
+	lines := app.detailLines(&app.entries[app.visible[app.cursor]])
+	detailHeight := height - top - 2
+	app.detailOffset = clampInt(app.detailOffset, 0, maxInt(len(lines)-detailHeight, 0))
+	for row := 0; row < detailHeight && app.detailOffset+row < len(lines); row++ {
+		line := lines[app.detailOffset+row]
+		style := tcell.StyleDefault
+		if strings.HasPrefix(line, "── ") {
+			style = style.Bold(true).Foreground(tcell.ColorTeal)
+		}
+		drawTUIText(app.screen, 0, top+1+row, width, style, line)
+	}
+}
+
+// 详情内容（请求行、状态、请求头/响应头和格式化后的请求体/响应体）
+func (app *tuiApp) detailLines(item *tuiEntry) []string {
+	detail := app.analyzer.entryDetail(item.index, item.entry)
+	lines := []string{
+		detail.Method + " " + detail.URL,
+		fmt.Sprintf("%d %s · %s · %s · %s · %s", detail.Status, detail.StatusText, detail.Started,
+			formatDurationMillis(detail.Time), formatByteSize(detail.Size), detail.MimeType),
+	}
+	section := func(title string) {
+		lines = append(lines, "", "── "+title)
+	}
+
+	section(T("web.request_headers"))
+	for _, header := range detail.RequestHeaders {
+		lines = append(lines, header.Name+": "+header.Value)
+	}
+	if detail.RequestBody != "" {
+		section(T("web.request_body"))
+		lines = append(lines, prettyBodyLines(detail.RequestBody)...)
+	}
+	section(T("web.response_headers"))
+	for _, header := range detail.ResponseHeaders {
+		lines = append(lines, header.Name+": "+header.Value)
+	}
+	if detail.ResponseBody != "" {
+		section(T("web.response_body"))
+		lines = append(lines, prettyBodyLines(detail.ResponseBody)...)
+	}
+	return lines
+}
+
+// 绘制API表
+func (app *tuiApp) drawAPIs(width, height int) {
+	listHeight := height - 3
+	pathWidth := maxInt(width-7-24-8-7-9-5, 10)
+	header := strings.Join([]string{
+		fitText(T("col.method"), 7), fitText(T("col.host"), 24), fitText(T("col.path"), pathWidth),
+		fitText(T("col.calls"), 8), fitText(T("col.errors"), 7), fitText("P95", 9),
+	}, " ")
+	drawTUIText(app.screen, 0, 1, width, tcell.StyleDefault.Bold(true), header)
+
+	if app.apiCursor < app.apiOffset {
+		app.apiOffset = app.apiCursor
+	}
+	if app.apiCursor >= app.apiOffset+listHeight {
+		app.apiOffset = app.apiCursor - listHeight + 1
+	}
+	for row := 0; row < listHeight && app.apiOffset+row < len(app.endpoints); row++ {
+		endpoint := app.endpoints[app.apiOffset+row]
+		line := strings.Join([]string{
+			fitText(endpoint.Method, 7), fitText(endpoint.Host, 24), fitText(endpointDisplayPath(endpoint), pathWidth),
+			fitText(strconv.Itoa(endpoint.TotalCalls), 8), fitText(strconv.Itoa(endpoint.Errors), 7),
+			fitText(formatDurationMillis(endpoint.Latency.P95), 9),
+		}, " ")
+		style := tcell.StyleDefault
+		if endpoint.Errors > 0 {
+			style = style.Foreground(tcell.ColorRed)
+		}
+		if app.apiOffset+row == app.apiCursor {
+			style = style.Reverse(true)
+		}
+		drawTUIText(app.screen, 0, 2+row, width, style, padRight(line, width))
+	}
+}
+
+// API的显示路径（SOAP接口附带操作名，与汇总报告一致）
+func endpointDisplayPath(endpoint SummaryEndpoint) string {
+	if endpoint.Operation != "" {
+		return endpoint.Path + " (" + endpoint.Operation + ")"
+	}
+	return endpoint.Path
+}
+
+// 请求体/响应体为JSON时格式化
+func prettyBodyLines(body string) []string {
+	var pretty bytes.Buffer
+	if json.Indent(&pretty, []byte(body), "", "  ") == nil {
+		body = pretty.String()
+	}
+	return strings.Split(strings.ReplaceAll(body, "\r\n", "\n"), "\n")
+}
+
+// 在指定位置绘制文本（按显示宽度截断，正确处理中文等宽字符）
+func drawTUIText(screen tcell.Screen, x, y, maxWidth int, style tcell.Style, text string) {
+	for _, r := range text {
+		if r == '\t' {
+			r = ' '
+		}
+		w := runewidth.RuneWidth(r)
+		if w == 0 {
+			continue
+		}
+		if x+w > maxWidth {
+			return
+		}
+		screen.SetContent(x, y, r, nil, style)
+		x += w
+	}
+}
+
+// 截断或补齐到指定显示宽度
+func fitText(text string, width int) string {
+	return runewidth.FillRight(runewidth.Truncate(text, width, "…"), width)
+}
+
+// 补齐到指定显示宽度
+func padRight(text string, width int) string {
+	return runewidth.FillRight(text, width)
+}
+
+func clampInt(value, low, high int) int {
+	if value > high {
+		value = high
+	}
+	if value < low {
+		value = low
+	}
+	return value
+}
+
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,378 @@
+package main
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	htmltemplate "html/template"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	texttemplate "text/template"
+	"time"
+)
+
+// 内置报告模板
+//
+//go:embed templates/*.tmpl
+var builtinTemplateFS embed.FS
+
+// 内置模板名称及对应文件
+var builtinReportTemplates = map[string]string{
+	"markdown": "templates/report.md.tmpl",
+	"html":     "templates/report.html.tmpl",
+}
+
+// 默认报告模板
+const defaultReportTemplate = "markdown"
+
+// 报告模板（扩展名为 .html/.htm 时使用 html/template 自动转义）
+type ReportTemplate struct {
+	name string
+	ext  string // 生成报告的扩展名
+	text *texttemplate.Template
+	html *htmltemplate.Template
+}
+
+// 统计项
+type CountItem struct {
+	Name  string
+	Count int
+}
+
+// 按阈值过滤、排序并截断后的统计表
+type CountTable struct {
+	Items     []CountItem // 显示的项（最多 maxItems 项）
+	Total     int         // 超过阈值的总项数
+	Truncated bool        // 是否有未显示的项
+}
+
+// 设置报告模板：内置模板名（markdown、html）或模板文件路径
+func (ua *UniversalHARAnalyzer) SetReportTemplate(name string) error {
+	if name == "" {
+		name = defaultReportTemplate
+	}
+
+	var content []byte
+	var err error
+	templateName := name
+	if file, ok := builtinReportTemplates[name]; ok {
+		content, err = builtinTemplateFS.ReadFile(file)
+		templateName = filepath.Base(file)
+	} else {
+		content, err = os.ReadFile(name)
+		templateName = filepath.Base(name)
+	}
+	if err != nil {
+		return fmt.Errorf("%s: %w", T("template.read_failed", name), err)
+	}
+
+	tmpl := &ReportTemplate{name: templateName, ext: reportTemplateExt(templateName)}
+	if tmpl.ext == ".html" || tmpl.ext == ".htm" {
+		tmpl.html, err = htmltemplate.New(templateName).Funcs(htmltemplate.FuncMap(ua.templateFuncs())).Parse(string(content))
+	} else {
+		tmpl.text, err = texttemplate.New(templateName).Funcs(texttemplate.FuncMap(ua.templateFuncs())).Parse(string(content))
+	}
+	if err != nil {
+		return fmt.Errorf("%s: %w", T("template.parse_failed", name), err)
+	}
+	ua.reportTemplate = tmpl
+	return nil
+}
+
+// 根据模板文件名确定报告扩展名（report.html.tmpl -> .html，无扩展名时为 .md）
+func reportTemplateExt(name string) string {
+	for _, suffix := range []string{".tmpl", ".tpl", ".gotmpl"} {
+		name = strings.TrimSuffix(name, suffix)
+	}
+	if ext := strings.ToLower(filepath.Ext(name)); ext != "" {
+		return ext
+	}
+	return ".md"
+}
+
+// 使用报告模板渲染分析结果
+func (ua *UniversalHARAnalyzer) renderReport(result *UniversalAnalysisResult) ([]byte, string, error) {
+	if ua.reportTemplate == nil {
+		if err := ua.SetReportTemplate(defaultReportTemplate); err != nil {
+			return nil, "", err
+		}
+	}
+
+	var buf bytes.Buffer
+	var err error
+	if ua.reportTemplate.html != nil {
+		err = ua.reportTemplate.html.Execute(&buf, result)
+	} else {
+		err = ua.reportTemplate.text.Execute(&buf, result)
+	}
+	if err != nil {
+		return nil, "", fmt.Errorf("%s: %w", T("template.render_failed", ua.reportTemplate.name), err)
+	}
+	return buf.Bytes(), ua.reportTemplate.ext, nil
+}
+
+// 使用内置的文本模板（summary.md.tmpl、load.md.tmpl）渲染数据并写入文件
+func (ua *UniversalHARAnalyzer) writeBuiltinTemplate(name, path string, data interface{}) error {
+	content, err := builtinTemplateFS.ReadFile("templates/" + name)
+	if err != nil {
+		return err
+	}
+	tmpl, err := texttemplate.New(name).Funcs(ua.templateFuncs()).Parse(string(content))
+	if err != nil {
+		return err
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	if err := tmpl.Execute(file, data); err != nil {
+		return fmt.Errorf("%s: %w", T("template.render_failed", name), err)
+	}
+	return file.Close()
+}
+
+// 模板辅助函数
+func (ua *UniversalHARAnalyzer) templateFuncs() map[string]interface{} {
+	return map[string]interface{}{
+		// 本地化消息
+		"t": T,
+		// 配置
+		"section":    ua.sectionEnabled,
+		"threshold":  ua.templateThreshold,
+		"maxItems":   func() int { return ua.settings().MaxItems },
+		"pageBudget": ua.pageBudget,
+		"budget":     ua.budgetViolations,
+		// 统计与排序
+		"counts":     ua.countTable,
+		"sortCounts": sortCounts,
+		"sortBy":     func(field string, list interface{}) (interface{}, error) { return sortByField(field, list, false) },
+		"sortByDesc": func(field string, list interface{}) (interface{}, error) { return sortByField(field, list, true) },
+		"top":        topN,
+		// 格式化
+		"duration":   formatDurationMillis,
+		"bytes":      formatByteSize,
+		"formatTime": func(t time.Time, layout string) string { return t.Format(layout) },
+		"percent":    formatPercent,
+		"join":       strings.Join,
+		"sortedKeys": sortedMapKeys,
+		"tableHeader": func(headers ...string) string {
+			var header strings.Builder
+			writeTableHeader(&header, headers...)
+			return strings.TrimSuffix(header.String(), "\n")
+		},
+		// 其他
+		"add":  func(a, b int) int { return a + b },
+		"sub":  func(a, b int) int { return a - b },
+		"dict": templateDict,
+	}
+}
+
+// 按名称获取报告阈值
+func (ua *UniversalHARAnalyzer) templateThreshold(name string) (int, error) {
+	thresholds := ua.settings().Thresholds
+	switch name {
+	case "apiCalls":
+		return thresholds.APICalls, nil
+	case "parameters":
+		return thresholds.Parameters, nil
+	case "headers":
+		return thresholds.Headers, nil
+	case "templateHeaders":
+		return thresholds.TemplateHeaders, nil
+	case "statistics":
+		return thresholds.Statistics, nil
+	}
+	return 0, fmt.Errorf("%s", T("template.unknown_threshold", name))
+}
+
+// 生成统计表：只保留出现次数大于 minCount 的项，按次数降序排列，最多 maxItems 项
+func (ua *UniversalHARAnalyzer) countTable(items map[string]int, minCount int) CountTable {
+	var selected []CountItem
+	for _, item := range sortCounts(items) {
+		if item.Count > minCount {
+			selected = append(selected, item)
+		}
+	}
+
+	table := CountTable{Items: selected, Total: len(selected)}
+	if maxItems := ua.settings().MaxItems; len(selected) > maxItems {
+		table.Items = selected[:maxItems]
+		table.Truncated = true
+	}
+	return table
+}
+
+// 将统计map转换为按次数降序（次数相同时按名称）排列的列表
+func sortCounts(items map[string]int) []CountItem {
+	sorted := make([]CountItem, 0, len(items))
+	for name, count := range items {
+		sorted = append(sorted, CountItem{Name: name, Count: count})
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].Count != sorted[j].Count {
+			return sorted[i].Count > sorted[j].Count
+		}
+		return sorted[i].Name < sorted[j].Name
+	})
+	return sorted
+}
+
+// 按结构体字段排序切片（返回排序后的副本）
+func sortByField(field string, list interface{}, descending bool) (interface{}, error) {
+	value := reflect.ValueOf(list)
+	if value.Kind() != reflect.Slice {
+		return nil, fmt.Errorf("%s", T("template.not_a_list", "sortBy"))
+	}
+
+	sorted := reflect.MakeSlice(value.Type(), value.Len(), value.Len())
+	reflect.Copy(sorted, value)
+
+	keys := make([]reflect.Value, sorted.Len())
+	for i := range keys {
+		element := reflect.Indirect(sorted.Index(i))
+		if element.Kind() != reflect.Struct {
+			return nil, fmt.Errorf("%s", T("template.unknown_field", field))
+		}
+		keys[i] = element.FieldByName(field)
+		if !keys[i].IsValid() {
+			return nil, fmt.Errorf("%s", T("template.unknown_field", field))
+		}
+	}
+
+	less := func(a, b reflect.Value) bool {
+		switch a.Kind() {
+		case reflect.Int, reflect.Int64, reflect.Int32:
+			return a.Int() < b.Int()
+		case reflect.Float64, reflect.Float32:
+			return a.Float() < b.Float()
+		}
+		return fmt.Sprint(a.Interface()) < fmt.Sprint(b.Interface())
+	}
+
+	indexes := make([]int, len(keys))
+	for i := range indexes {
+		indexes[i] = i
+	}
+	sort.SliceStable(indexes, func(i, j int) bool {
+		if descending {
+			return less(keys[indexes[j]], keys[indexes[i]])
+		}
+		return less(keys[indexes[i]], keys[indexes[j]])
+	})
+
+	result := reflect.MakeSlice(value.Type(), len(indexes), len(indexes))
+	for i, index := range indexes {
+		result.Index(i).Set(sorted.Index(index))
+	}
+	return result.Interface(), nil
+}
+
+// 取切片的前n项
+func topN(n int, list interface{}) (interface{}, error) {
+	value := reflect.ValueOf(list)
+	if value.Kind() != reflect.Slice {
+		return nil, fmt.Errorf("%s", T("template.not_a_list", "top"))
+	}
+	if n < 0 {
+		n = 0
+	}
+	if n > value.Len() {
+		n = value.Len()
+	}
+	return value.Slice(0, n).Interface(), nil
+}
+
+// 格式化毫秒数（如 850ms、1.50s、2m5s）
+func formatDurationMillis(value interface{}) string {
+	var ms float64
+	switch v := value.(type) {
+	case float64:
+		ms = v
+	case int:
+		ms = float64(v)
+	case int64:
+		ms = float64(v)
+	case time.Duration:
+		ms = float64(v) / float64(time.Millisecond)
+	}
+
+	switch {
+	case ms < 0:
+		return "-"
+	case ms < 1000:
+		return fmt.Sprintf("%.0fms", ms)
+	case ms < 60000:
+		return fmt.Sprintf("%.2fs", ms/1000)
+	}
+	return (time.Duration(ms/1000) * time.Second).String()
+}
+
+// 格式化字节数（如 512 B、1.5 KB、2.3 MB）
+func formatByteSize(value interface{}) string {
+	var size float64
+	switch v := value.(type) {
+	case float64:
+		size = v
+	case int:
+		size = float64(v)
+	case int64:
+		size = float64(v)
+	}
+	if size < 0 {
+		return "-"
+	}
+
+	units := []string{"B", "KB", "MB", "GB", "TB"}
+	unit := 0
+	for size >= 1024 && unit < len(units)-1 {
+		size /= 1024
+		unit++
+	}
+	if unit == 0 {
+		return fmt.Sprintf("%.0f %s", size, units[unit])
+	}
+	return fmt.Sprintf("%.1f %s", size, units[unit])
+}
+
+// 格式化百分比
+func formatPercent(part, total int) string {
+	if total == 0 {
+		return "0.0%"
+	}
+	return fmt.Sprintf("%.1f%%", float64(part)*100/float64(total))
+}
+
+// 按键排序的map键列表
+func sortedMapKeys(m interface{}) ([]string, error) {
+	value := reflect.ValueOf(m)
+	if value.Kind() != reflect.Map {
+		return nil, fmt.Errorf("%s", T("template.not_a_map", "sortedKeys"))
+	}
+	keys := make([]string, 0, value.Len())
+	for _, key := range value.MapKeys() {
+		keys = append(keys, fmt.Sprint(key.Interface()))
+	}
+	sort.Strings(keys)
+	return keys, nil
+}
+
+// 构造传给子模板的参数（键值交替）
+func templateDict(pairs ...interface{}) (map[string]interface{}, error) {
+	if len(pairs)%2 != 0 {
+		return nil, fmt.Errorf("%s", T("template.invalid_dict"))
+	}
+	dict := make(map[string]interface{}, len(pairs)/2)
+	for i := 0; i < len(pairs); i += 2 {
+		key, ok := pairs[i].(string)
+		if !ok {
+			return nil, fmt.Errorf("%s", T("template.invalid_dict"))
+		}
+		dict[key] = pairs[i+1]
+	}
+	return dict, nil
+}
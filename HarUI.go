@@ -0,0 +1,418 @@
+package main
+
+import (
+	"crypto/sha256"
+	"embed"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// 内嵌的单页应用
+//
+//go:embed web
+var webUIFS embed.FS
+
+// 默认监听地址（只监听本机）
+const defaultUIAddr = "127.0.0.1:8765"
+
+// 上传文件大小上限
+const uiMaxUploadBytes = 512 << 20
+
+// Web界面服务
+type uiServer struct {
+	analyzer *UniversalHARAnalyzer
+	dir      string
+	addr     string // 实际监听地址
+
+	mu    sync.Mutex
+	files map[string]*uiFile
+}
+
+// Web界面中的HAR文件（目录中的文件首次打开时解析，上传的文件保存在内存中）
+type uiFile struct {
+	ID       string `json:"id"`
+	Name     string `json:"name"`
+	Path     string `json:"path,omitempty"`
+	Size     int64  `json:"size"`
+	Uploaded bool   `json:"uploaded"`
+
+	modTime time.Time // 文件修改时间（大小或修改时间变化时重新分析）
+	har     *UniversalHARFile
+	result  *UniversalAnalysisResult
+}
+
+// 单个请求的详细信息
+type EntryDetail struct {
+	Index           int            `json:"index"`
+	Started         string         `json:"started"`
+	Method          string         `json:"method"`
+	URL             string         `json:"url"`
+	Host            string         `json:"host"`
+	Path            string         `json:"path"`
+	Status          int            `json:"status"`
+	StatusText      string         `json:"statusText"`
+	Time            float64        `json:"time"`
+	MimeType        string         `json:"mimeType"`
+	Size            float64        `json:"size"`
+	RequestHeaders  []HARNameValue `json:"requestHeaders"`
+	ResponseHeaders []HARNameValue `json:"responseHeaders"`
+	RequestBody     string         `json:"requestBody"`
+	ResponseBody    string         `json:"responseBody"`
+}
+
+// 运行 ui 子命令
+func runUICommand(args []string) error {
+	analyzer := NewUniversalHARAnalyzer()
+
+	flags := flag.NewFlagSet("ui", flag.ExitOnError)
+	addr := flags.String("addr", defaultUIAddr, T("flag.ui_addr"))
+	filter := flags.String("filter", "", T("flag.filter"))
+	excludeStatic := flags.Bool("exclude-static", false, T("flag.exclude_static"))
+	columns := flags.String("columns", "", T("flag.columns"))
+	reportTemplate := flags.String("template", defaultReportTemplate, T("flag.template"))
+	configPath := addConfigFlag(flags)
+	addLanguageFlag(flags)
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), T("ui.usage", filepath.Base(os.Args[0])))
+		flags.PrintDefaults()
+	}
+	flags.Parse(args)
+
+	if err := analyzer.Configure(flags, *configPath, *filter, *excludeStatic); err != nil {
+		return err
+	}
+	if err := analyzer.SetReportTemplate(*reportTemplate); err != nil {
+		return err
+	}
+	if err := analyzer.SetExportColumns(*columns); err != nil {
+		return err
+	}
+
+	dir, _ := os.Getwd()
+	if flags.NArg() > 0 {
+		dir = flags.Arg(0)
+	}
+
+	server := &uiServer{analyzer: analyzer, dir: dir, files: make(map[string]*uiFile)}
+	listener, err := net.Listen("tcp", *addr)
+	if err != nil {
+		return err
+	}
+	server.addr = listener.Addr().String()
+	fmt.Println("🌐 " + T("ui.listening", "http://"+server.addr, dir))
+	httpServer := &http.Server{Handler: server.routes(), ReadHeaderTimeout: 10 * time.Second}
+	return httpServer.Serve(listener)
+}
+
+// 注册路由
+func (s *uiServer) routes() http.Handler {
+	static, _ := fs.Sub(webUIFS, "web")
+
+	mux := http.NewServeMux()
+	mux.Handle("GET /", http.FileServerFS(static))
+	mux.HandleFunc("GET /api/messages", s.handleMessages)
+	mux.HandleFunc("GET /api/files", s.handleFiles)
+	mux.HandleFunc("POST /api/files", s.handleUpload)
+	mux.HandleFunc("GET /api/files/{id}/analysis", s.handleAnalysis)
+	mux.HandleFunc("GET /api/files/{id}/calls", s.handleCalls)
+	mux.HandleFunc("GET /api/files/{id}/export/{format}", s.handleExport)
+	mux.HandleFunc("GET /api/compare", s.handleCompare)
+	return s.checkHost(mux)
+}
+
+// 拒绝 Host 不是监听地址或本机的请求（界面会返回原始HAR数据，防止DNS重绑定）
+func (s *uiServer) checkHost(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !s.allowedHost(r.Host) {
+			writeJSONError(w, http.StatusForbidden, fmt.Errorf("%s", T("ui.forbidden_host", r.Host, s.addr)))
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// Host 是否为监听地址、localhost 或 127.0.0.1（不比较端口；监听所有地址时接受任意IP，域名仍会被拒绝）
+func (s *uiServer) allowedHost(host string) bool {
+	if name, _, err := net.SplitHostPort(host); err == nil {
+		host = name
+	}
+	host = strings.ToLower(strings.Trim(host, "[]"))
+	switch host {
+	case "localhost", "127.0.0.1", "::1":
+		return true
+	}
+	listenHost, _, err := net.SplitHostPort(s.addr)
+	if err != nil {
+		return false
+	}
+	if ip := net.ParseIP(listenHost); ip != nil && ip.IsUnspecified() {
+		return net.ParseIP(host) != nil
+	}
+	return host == strings.ToLower(listenHost)
+}
+
+// 当前语言的消息（界面文本使用 web. 开头的消息，表格列名与报告共用）
+func (s *uiServer) handleMessages(w http.ResponseWriter, r *http.Request) {
+	messages := make(map[string]string)
+	for key := range messageCatalogs["zh"] {
+		messages[key] = T(key)
+	}
+	writeJSON(w, map[string]interface{}{"language": currentLanguage, "messages": messages})
+}
+
+// 目录中的HAR文件和已上传的文件
+func (s *uiServer) handleFiles(w http.ResponseWriter, r *http.Request) {
+	paths, err := s.analyzer.ScanHARFiles(s.dir)
+	if err != nil {
+		writeJSONError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	seen := make(map[string]bool)
+	for _, path := range paths {
+		id := uiFileID(path)
+		seen[id] = true
+		info, err := os.Stat(path)
+		if err != nil {
+			continue
+		}
+		if file, ok := s.files[id]; ok && file.Size == info.Size() && file.modTime.Equal(info.ModTime()) {
+			continue
+		}
+		name := path
+		if rel, err := filepath.Rel(s.dir, path); err == nil {
+			name = filepath.ToSlash(rel)
+		}
+		s.files[id] = &uiFile{ID: id, Name: name, Path: path, Size: info.Size(), modTime: info.ModTime()}
+	}
+
+	list := make([]*uiFile, 0, len(s.files))
+	for id, file := range s.files {
+		if !file.Uploaded && !seen[id] {
+			delete(s.files, id)
+			continue
+		}
+		list = append(list, file)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		if list[i].Uploaded != list[j].Uploaded {
+			return !list[i].Uploaded
+		}
+		return list[i].Name < list[j].Name
+	})
+	writeJSON(w, list)
+}
+
+// 上传HAR文件（multipart字段 file）
+func (s *uiServer) handleUpload(w http.ResponseWriter, r *http.Request) {
+	r.Body = http.MaxBytesReader(w, r.Body, uiMaxUploadBytes)
+	upload, header, err := r.FormFile("file")
+	if err != nil {
+		writeJSONError(w, http.StatusBadRequest, err)
+		return
+	}
+	defer upload.Close()
+
+	data, err := io.ReadAll(upload)
+	if err != nil {
+		writeJSONError(w, http.StatusBadRequest, err)
+		return
+	}
+	var har UniversalHARFile
+	if err := json.Unmarshal(data, &har); err != nil {
+		writeJSONError(w, http.StatusBadRequest, fmt.Errorf("%s: %w", T("err.parse_har"), err))
+		return
+	}
+
+	hash := sha256.Sum256(data)
+	file := &uiFile{
+		ID:       "upload-" + hex.EncodeToString(hash[:6]),
+		Name:     filepath.Base(header.Filename),
+		Size:     int64(len(data)),
+		Uploaded: true,
+		har:      &har,
+	}
+	s.mu.Lock()
+	s.files[file.ID] = file
+	s.mu.Unlock()
+	writeJSON(w, file)
+}
+
+// 单个文件的分析结果
+func (s *uiServer) handleAnalysis(w http.ResponseWriter, r *http.Request) {
+	file, err := s.loadFile(r.PathValue("id"))
+	if err != nil {
+		writeJSONError(w, http.StatusNotFound, err)
+		return
+	}
+	writeJSON(w, file.result)
+}
+
+// 指定API（method、path、operation参数）的所有调用
+func (s *uiServer) handleCalls(w http.ResponseWriter, r *http.Request) {
+	file, err := s.loadFile(r.PathValue("id"))
+	if err != nil {
+		writeJSONError(w, http.StatusNotFound, err)
+		return
+	}
+
+	entries := s.analyzer.selectEntries(file.har.Log.Entries)
+	query := r.URL.Query()
+	calls := []EntryDetail{}
+	for i, entry := range s.analyzer.apiEntries(entries, query.Get("method"), query.Get("path"), query.Get("operation")) {
+		calls = append(calls, s.analyzer.entryDetail(i, &entry))
+	}
+	writeJSON(w, calls)
+}
+
+// 下载导出文件: csv、ndjson、json（分析结果）、report（报告模板）
+func (s *uiServer) handleExport(w http.ResponseWriter, r *http.Request) {
+	file, err := s.loadFile(r.PathValue("id"))
+	if err != nil {
+		writeJSONError(w, http.StatusNotFound, err)
+		return
+	}
+
+	baseName := strings.TrimSuffix(filepath.Base(file.Name), filepath.Ext(file.Name))
+	var body []byte
+	var fileName, contentType string
+	switch format := r.PathValue("format"); format {
+	case "csv", "ndjson":
+		var buf strings.Builder
+		entries := s.analyzer.selectEntries(file.har.Log.Entries)
+		if format == "csv" {
+			err = s.analyzer.encodeEntriesCSV(&buf, entries)
+			contentType = "text/csv; charset=utf-8"
+		} else {
+			err = s.analyzer.encodeEntriesNDJSON(&buf, entries)
+			contentType = "application/x-ndjson"
+		}
+		body, fileName = []byte(buf.String()), baseName+"_entries."+format
+	case "json":
+		body, err = json.MarshalIndent(file.result, "", "  ")
+		fileName, contentType = baseName+"_analysis.json", "application/json"
+	case "report":
+		var ext string
+		body, ext, err = s.analyzer.renderReport(file.result)
+		fileName, contentType = baseName+"_report"+ext, "text/plain; charset=utf-8"
+		if ext == ".html" || ext == ".htm" {
+			contentType = "text/html; charset=utf-8"
+		}
+	default:
+		writeJSONError(w, http.StatusBadRequest, fmt.Errorf("%s", T("err.unsupported_export", format, "csv, ndjson, json, report")))
+		return
+	}
+	if err != nil {
+		writeJSONError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", fileName))
+	w.Write(body)
+}
+
+// 对比两个文件（a、b参数）
+func (s *uiServer) handleCompare(w http.ResponseWriter, r *http.Request) {
+	var results []*UniversalAnalysisResult
+	for _, id := range []string{r.URL.Query().Get("a"), r.URL.Query().Get("b")} {
+		file, err := s.loadFile(id)
+		if err != nil {
+			writeJSONError(w, http.StatusNotFound, err)
+			return
+		}
+		results = append(results, file.result)
+	}
+	writeJSON(w, BuildSummary(results))
+}
+
+// 获取文件并在首次访问时解析和分析
+func (s *uiServer) loadFile(id string) (*uiFile, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	file, ok := s.files[id]
+	if !ok {
+		return nil, fmt.Errorf("%s", T("ui.file_not_found", id))
+	}
+	if file.har == nil {
+		har, err := s.analyzer.LoadHARFile(file.Path)
+		if err != nil {
+			return nil, err
+		}
+		file.har = har
+	}
+	if file.result == nil {
+		file.result = s.analyzer.AnalyzeHAR(file.har, file.Name)
+		file.result.Metadata.RelativePath = file.Name
+	}
+	return file, nil
+}
+
+// 构造请求详细信息（按脱敏规则处理URL、请求头和请求/响应体）
+func (ua *UniversalHARAnalyzer) entryDetail(index int, entry *HAREntry) EntryDetail {
+	detail := EntryDetail{
+		Index:           index,
+		Started:         entry.StartedDateTime,
+		Method:          entry.Request.Method,
+		URL:             ua.redactURL(entry.Request.URL),
+		Host:            ua.extractHost(entry.Request.URL),
+		Path:            ua.extractPath(entry.Request.URL),
+		Status:          entry.Response.Status,
+		StatusText:      entry.Response.StatusText,
+		Time:            roundMillis(entry.Time),
+		MimeType:        entry.Response.Content.MimeType,
+		Size:            entry.Response.Content.Size,
+		RequestHeaders:  make([]HARNameValue, 0, len(entry.Request.Headers)),
+		ResponseHeaders: make([]HARNameValue, 0, len(entry.Response.Headers)),
+		RequestBody:     ua.redactValue(entry.Request.PostData.Text),
+		ResponseBody:    ua.redactValue(entry.Response.Content.Text),
+	}
+	for _, header := range entry.Request.Headers {
+		detail.RequestHeaders = append(detail.RequestHeaders, HARNameValue{Name: header.Name, Value: ua.redactHeader(header.Name, header.Value)})
+	}
+	for _, header := range entry.Response.Headers {
+		detail.ResponseHeaders = append(detail.ResponseHeaders, HARNameValue{Name: header.Name, Value: ua.redactHeader(header.Name, header.Value)})
+	}
+	return detail
+}
+
+// 文件ID（路径的哈希，避免在URL中出现路径）
+func uiFileID(path string) string {
+	hash := sha256.Sum256([]byte(path))
+	return hex.EncodeToString(hash[:6])
+}
+
+// 输出JSON响应
+func writeJSON(w http.ResponseWriter, value interface{}) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-store")
+	json.NewEncoder(w).Encode(value)
+}
+
+// 输出JSON错误
+func writeJSONError(w http.ResponseWriter, status int, err error) {
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		status = http.StatusRequestEntityTooLarge
+	}
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+}
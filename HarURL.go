@@ -0,0 +1,206 @@
+package main
+
+import (
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"golang.org/x/net/idna"
+)
+
+// 规范化后的URL
+type NormalizedURL struct {
+	Scheme string         // 小写协议（http、https、ws、wss...）
+	Host   string         // 小写主机名（IDN转换为ASCII形式，IPv6不带方括号，不含用户信息）
+	Port   int            // 端口（未指定时为协议默认端口，未知协议为0）
+	Path   string         // 规范化路径（统一百分号编码，去除 . 和 .. 段）
+	Query  []HARNameValue // 按原始顺序解析的查询参数（已解码）
+}
+
+// 各协议的默认端口
+var defaultPorts = map[string]int{
+	"http":  80,
+	"https": 443,
+	"ws":    80,
+	"wss":   443,
+	"ftp":   21,
+}
+
+// 解析并规范化URL（无法解析时主机为空、路径为 /）
+func normalizeURL(rawURL string) NormalizedURL {
+	normalized := NormalizedURL{Path: "/"}
+
+	parsed, err := url.Parse(strings.TrimSpace(rawURL))
+	if err != nil {
+		// 修正无效的百分号编码后重试
+		if parsed, err = url.Parse(fixPercentEncoding(strings.TrimSpace(rawURL))); err != nil {
+			return normalized
+		}
+	}
+
+	normalized.Scheme = strings.ToLower(parsed.Scheme)
+	normalized.Query = parseQueryParams(parsed.RawQuery)
+	if parsed.Opaque != "" {
+		// mailto:、data: 等不含主机和路径的URL
+		return normalized
+	}
+
+	normalized.Host = normalizeHost(parsed.Hostname())
+	normalized.Port = defaultPorts[normalized.Scheme]
+	if port, err := strconv.Atoi(parsed.Port()); err == nil {
+		normalized.Port = port
+	}
+	normalized.Path = normalizePath(parsed.EscapedPath())
+	return normalized
+}
+
+// 主机和端口（省略协议默认端口，IPv6地址加方括号）
+func (u NormalizedURL) Authority() string {
+	host := u.Host
+	if strings.Contains(host, ":") {
+		host = "[" + host + "]"
+	}
+	if host == "" || u.Port == 0 || u.Port == defaultPorts[u.Scheme] {
+		return host
+	}
+	return net.JoinHostPort(u.Host, strconv.Itoa(u.Port))
+}
+
+// 规范化主机名（小写，国际化域名转换为ASCII形式，去除末尾的点）
+func normalizeHost(host string) string {
+	host = strings.TrimSuffix(strings.ToLower(host), ".")
+	for i := 0; i < len(host); i++ {
+		if host[i] >= 0x80 {
+			if ascii, err := idna.Lookup.ToASCII(host); err == nil {
+				return ascii
+			}
+			break
+		}
+	}
+	return host
+}
+
+// 规范化路径：解码非保留字符、百分号编码统一大写、去除 . 和 .. 段（RFC 3986 第6.2.2节）
+func normalizePath(escapedPath string) string {
+	var builder strings.Builder
+	for i := 0; i < len(escapedPath); i++ {
+		c := escapedPath[i]
+		if c == '%' && i+2 < len(escapedPath) && isHexDigit(escapedPath[i+1]) && isHexDigit(escapedPath[i+2]) {
+			value, _ := strconv.ParseUint(escapedPath[i+1:i+3], 16, 8)
+			if isUnreservedChar(byte(value)) {
+				builder.WriteByte(byte(value))
+			} else {
+				builder.WriteString(strings.ToUpper(escapedPath[i : i+3]))
+			}
+			i += 2
+			continue
+		}
+		builder.WriteByte(c)
+	}
+
+	path := removeDotSegments(builder.String())
+	if !strings.HasPrefix(path, "/") {
+		path = "/" + path
+	}
+	return path
+}
+
+// 去除路径中的 . 和 .. 段（保留空段和末尾的斜杠）
+func removeDotSegments(path string) string {
+	if !strings.Contains(path, ".") {
+		return path
+	}
+
+	segments := strings.Split(path, "/")
+	output := make([]string, 0, len(segments))
+	for i, segment := range segments {
+		last := i == len(segments)-1
+		switch segment {
+		case ".":
+			if last {
+				output = append(output, "")
+			}
+		case "..":
+			if len(output) > 1 {
+				output = output[:len(output)-1]
+			}
+			if last {
+				output = append(output, "")
+			}
+		default:
+			output = append(output, segment)
+		}
+	}
+	return strings.Join(output, "/")
+}
+
+// 按原始顺序解析查询字符串（无法解码的部分保留原文）
+func parseQueryParams(rawQuery string) []HARNameValue {
+	var params []HARNameValue
+	for _, pair := range strings.Split(rawQuery, "&") {
+		if pair == "" {
+			continue
+		}
+		rawName, rawValue, _ := strings.Cut(pair, "=")
+		name, err := url.QueryUnescape(rawName)
+		if err != nil {
+			name = rawName
+		}
+		value, err := url.QueryUnescape(rawValue)
+		if err != nil {
+			value = rawValue
+		}
+		if name != "" {
+			params = append(params, HARNameValue{Name: name, Value: value})
+		}
+	}
+	return params
+}
+
+// 合并查询参数：以HAR中的 queryString 为准，补充其中缺失的URL参数
+func mergeQueryParams(queryString, parsed []HARNameValue) []HARNameValue {
+	if len(parsed) == 0 {
+		return queryString
+	}
+
+	listed := make(map[string]bool, len(queryString))
+	for _, param := range queryString {
+		listed[param.Name] = true
+	}
+	merged := append([]HARNameValue(nil), queryString...)
+	for _, param := range parsed {
+		if !listed[param.Name] {
+			merged = append(merged, param)
+		}
+	}
+	return merged
+}
+
+// 请求的所有查询参数
+func (ua *UniversalHARAnalyzer) queryParams(entry *HAREntry) []HARNameValue {
+	return mergeQueryParams(entry.Request.QueryString, normalizeURL(entry.Request.URL).Query)
+}
+
+// 将无效的百分号编码（如 %zz 或末尾的 %）替换为 %25
+func fixPercentEncoding(rawURL string) string {
+	var builder strings.Builder
+	for i := 0; i < len(rawURL); i++ {
+		if rawURL[i] == '%' && !(i+2 < len(rawURL) && isHexDigit(rawURL[i+1]) && isHexDigit(rawURL[i+2])) {
+			builder.WriteString("%25")
+			continue
+		}
+		builder.WriteByte(rawURL[i])
+	}
+	return builder.String()
+}
+
+func isHexDigit(c byte) bool {
+	return '0' <= c && c <= '9' || 'a' <= c && c <= 'f' || 'A' <= c && c <= 'F'
+}
+
+// RFC 3986 非保留字符
+func isUnreservedChar(c byte) bool {
+	return 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' || '0' <= c && c <= '9' ||
+		c == '-' || c == '.' || c == '_' || c == '~'
+}
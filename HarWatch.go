@@ -0,0 +1,294 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/exec"
+	"os/signal"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// 监视中的文件状态
+type watchedFile struct {
+	size    int64
+	modTime time.Time
+}
+
+// HAR目录监视器（轮询方式，适用于本地目录和网络共享目录）
+type harWatcher struct {
+	analyzer   *UniversalHARAnalyzer
+	dirs       []string
+	settings   WatchConfig
+	pending    map[string]watchedFile              // 新增或变化、等待写入完成的文件
+	known      map[string]watchedFile              // 已处理的文件
+	results    map[string]*UniversalAnalysisResult // 已分析文件的结果
+	changed    []string                            // 上次更新汇总报告后分析的文件
+	dirty      bool                                // 汇总报告是否需要更新
+	lastChange time.Time
+}
+
+// 运行 watch 子命令
+func runWatchCommand(args []string) error {
+	analyzer := NewUniversalHARAnalyzer()
+	defaults := DefaultAnalyzerConfig().Watch
+
+	flags := flag.NewFlagSet("watch", flag.ExitOnError)
+	flags.StringVar(&analyzer.outputDir, "o", analyzer.outputDir, T("flag.output_dir"))
+	filter := flags.String("filter", "", T("flag.filter"))
+	excludeStatic := flags.Bool("exclude-static", false, T("flag.exclude_static"))
+	exports := flags.String("export", "", T("flag.export"))
+	columns := flags.String("columns", "", T("flag.columns"))
+	reportTemplate := flags.String("template", defaultReportTemplate, T("flag.template"))
+	interval := flags.Duration("interval", defaults.Interval, T("flag.watch_interval"))
+	settle := flags.Duration("settle", defaults.Settle, T("flag.watch_settle"))
+	debounce := flags.Duration("debounce", defaults.Debounce, T("flag.watch_debounce"))
+	gate := flags.String("gate", "", T("flag.watch_gate"))
+	addOutputNamingFlags(flags)
+	addCacheFlags(flags, analyzer)
+	addProtoFlag(flags)
+	configPath := addConfigFlag(flags)
+	addLanguageFlag(flags)
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), T("watch.usage", filepath.Base(os.Args[0])))
+		flags.PrintDefaults()
+	}
+	flags.Parse(args)
+
+	if err := analyzer.Configure(flags, *configPath, *filter, *excludeStatic); err != nil {
+		return err
+	}
+	if err := analyzer.SetReportTemplate(*reportTemplate); err != nil {
+		return err
+	}
+	if err := analyzer.SetExportFormats(*exports); err != nil {
+		return err
+	}
+	if err := analyzer.SetExportColumns(*columns); err != nil {
+		return err
+	}
+
+	// 命令行中显式指定的参数优先于配置文件
+	settings := analyzer.settings().Watch
+	flags.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case "interval":
+			settings.Interval = *interval
+		case "settle":
+			settings.Settle = *settle
+		case "debounce":
+			settings.Debounce = *debounce
+		case "gate":
+			settings.Gate = *gate
+		}
+	})
+	if err := settings.validate(); err != nil {
+		return err
+	}
+
+	dirs := flags.Args()
+	if len(dirs) == 0 {
+		currentDir, _ := os.Getwd()
+		dirs = []string{currentDir}
+	}
+	for _, dir := range dirs {
+		info, err := os.Stat(dir)
+		if err != nil {
+			return err
+		}
+		if !info.IsDir() {
+			return fmt.Errorf("%s", T("watch.not_directory", dir))
+		}
+	}
+	if err := os.MkdirAll(analyzer.outputDir, 0755); err != nil {
+		return fmt.Errorf("%s: %w", T("err.create_output_dir"), err)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	watcher := &harWatcher{
+		analyzer: analyzer,
+		dirs:     dirs,
+		settings: settings,
+		pending:  make(map[string]watchedFile),
+		known:    make(map[string]watchedFile),
+		results:  make(map[string]*UniversalAnalysisResult),
+	}
+	return watcher.run(ctx)
+}
+
+// 开始监视，直到收到中断信号
+func (w *harWatcher) run(ctx context.Context) error {
+	ua := w.analyzer
+	ua.beginOutput(w.dirs...)
+	if err := ua.beginExports(); err != nil {
+		return err
+	}
+
+	fmt.Println("👀 " + T("watch.start", strings.Join(w.dirs, ", "), w.settings.Interval))
+
+	ticker := time.NewTicker(w.settings.Interval)
+	defer ticker.Stop()
+
+	w.poll()
+	for {
+		w.flushIfSettled()
+
+		select {
+		case <-ctx.Done():
+			// 退出前写入尚未更新的汇总报告（不再执行检查命令）
+			fmt.Println("\n🛑 " + T("watch.stopping"))
+			ua.finishExports()
+			if w.dirty {
+				w.flush(false)
+			} else if err := ua.writeManifest(); err != nil {
+				fmt.Println("⚠️ " + T("output.manifest_failed", err))
+			}
+			fmt.Println("👋 " + T("watch.stopped"))
+			return nil
+		case <-ticker.C:
+			w.poll()
+		}
+	}
+}
+
+// 扫描所有监视目录
+func (w *harWatcher) poll() {
+	seen := make(map[string]bool)
+	complete := true
+	for _, dir := range w.dirs {
+		files, err := w.analyzer.ScanHARFiles(dir)
+		if err != nil {
+			// 扫描过程中文件被移动或删除时，下次轮询再处理
+			fmt.Println("⚠️ " + T("watch.scan_failed", dir, err))
+			complete = false
+		}
+		for _, path := range files {
+			seen[path] = true
+			w.check(path)
+		}
+	}
+	if !complete {
+		return
+	}
+
+	// 已删除的文件从汇总报告中移除
+	for path := range w.pending {
+		if !seen[path] {
+			delete(w.pending, path)
+		}
+	}
+	for path := range w.known {
+		if !seen[path] {
+			delete(w.known, path)
+			if _, ok := w.results[path]; ok {
+				delete(w.results, path)
+				w.dirty = true
+				w.lastChange = time.Now()
+				fmt.Println("🗑️ " + T("watch.removed", path))
+			}
+		}
+	}
+}
+
+// 检查单个文件，写入完成后进行分析
+func (w *harWatcher) check(path string) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return
+	}
+	state := watchedFile{size: info.Size(), modTime: info.ModTime()}
+	if known, ok := w.known[path]; ok && known == state {
+		return
+	}
+
+	// 修改时间足够久且两次轮询之间大小不变时视为写入完成（首次发现的文件也要等下一次轮询，
+	// cp -p、rsync 等保留修改时间的复制可能还没写完）
+	previous, waiting := w.pending[path]
+	w.pending[path] = state
+	if !waiting || previous != state {
+		w.lastChange = time.Now()
+		return
+	}
+	if state.size == 0 || time.Since(state.modTime) < w.settings.Settle {
+		return
+	}
+
+	delete(w.pending, path)
+	w.known[path] = state
+	w.process(path)
+}
+
+// 分析写入完成的文件（失败的文件在下次变化时重试）
+func (w *harWatcher) process(path string) {
+	fmt.Println()
+	result, _, err := w.analyzer.processHARFile(path)
+	if err != nil {
+		fmt.Println("❌ " + T("analyze.failed", err))
+		return
+	}
+	w.results[path] = result
+	w.changed = append(w.changed, path)
+	w.dirty = true
+	w.lastChange = time.Now()
+}
+
+// 最后一次变化后经过防抖时长再更新汇总报告
+func (w *harWatcher) flushIfSettled() {
+	if w.dirty && time.Since(w.lastChange) >= w.settings.Debounce {
+		w.flush(true)
+	}
+}
+
+// 更新汇总报告，并按需执行检查命令
+func (w *harWatcher) flush(runGate bool) {
+	paths := make([]string, 0, len(w.results))
+	for path := range w.results {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	results := make([]*UniversalAnalysisResult, 0, len(paths))
+	for _, path := range paths {
+		results = append(results, w.results[path])
+	}
+	w.analyzer.updateSummary(results)
+	fmt.Println("📊 " + T("watch.summary_updated", len(results)))
+
+	if runGate && w.settings.Gate != "" && len(w.changed) > 0 {
+		w.runGate()
+	}
+	w.changed = nil
+	w.dirty = false
+}
+
+// 执行检查命令（通过环境变量传入输出目录、汇总数据和本次分析的文件）
+func (w *harWatcher) runGate() {
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.Command("cmd", "/C", w.settings.Gate)
+	} else {
+		cmd = exec.Command("sh", "-c", w.settings.Gate)
+	}
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	cmd.Env = append(os.Environ(),
+		"HAR_OUTPUT_DIR="+w.analyzer.outputDir,
+		"HAR_SUMMARY_JSON="+w.analyzer.artifactPath(summaryBaseName, summaryKind, ".json"),
+		"HAR_CHANGED_FILES="+strings.Join(w.changed, string(os.PathListSeparator)),
+	)
+
+	fmt.Println("🚦 " + T("watch.gate_running", w.settings.Gate))
+	if err := cmd.Run(); err != nil {
+		fmt.Println("❌ " + T("watch.gate_failed", err))
+		return
+	}
+	fmt.Println("✅ " + T("watch.gate_passed"))
+}
@@ -0,0 +1,282 @@
+package main
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// 用于区分消息类型的字段（按顺序查找第一个存在的字段）
+var messageDiscriminators = []string{"type", "event", "action", "op", "kind", "cmd", "command", "method", "channel", "topic", "msgType", "messageType"}
+
+// WebSocket帧的操作码
+const (
+	webSocketOpcodeText   = 1
+	webSocketOpcodeBinary = 2
+	webSocketOpcodeClose  = 8
+	webSocketOpcodePing   = 9
+	webSocketOpcodePong   = 10
+)
+
+// Chrome导出的WebSocket帧（_webSocketMessages）
+type HARWebSocketMessage struct {
+	Type   string  `json:"type"`   // send 或 receive
+	Time   float64 `json:"time"`   // 时间戳（秒）
+	Opcode int     `json:"opcode"` // 1 文本、2 二进制（base64）、8 关闭、9 ping、10 pong
+	Data   string  `json:"data"`
+}
+
+// WebSocket分析结果
+type WebSocketAnalysis struct {
+	Connections  []WebSocketConnection  `json:"connections"`       // 每个连接的统计
+	MessageTypes []WebSocketMessageType `json:"messageTypes"`      // 所有连接合并的消息类型
+	GoTypes      []string               `json:"goTypes,omitempty"` // 消息负载的Go结构体
+}
+
+// 单个WebSocket连接的统计
+type WebSocketConnection struct {
+	URL               string                 `json:"url"`
+	Host              string                 `json:"host"`
+	Path              string                 `json:"path"`
+	Started           string                 `json:"started"`
+	Duration          float64                `json:"duration"` // 第一帧到最后一帧的时长（毫秒）
+	Sent              int                    `json:"sent"`
+	Received          int                    `json:"received"`
+	SentBytes         int                    `json:"sentBytes"`
+	ReceivedBytes     int                    `json:"receivedBytes"`
+	TextFrames        int                    `json:"textFrames"`
+	BinaryFrames      int                    `json:"binaryFrames"`
+	ControlFrames     int                    `json:"controlFrames"` // close/ping/pong
+	MaxMessageSize    int                    `json:"maxMessageSize"`
+	MessagesPerSecond float64                `json:"messagesPerSecond"`
+	MessageTypes      []WebSocketMessageType `json:"messageTypes"`
+}
+
+// 按区分字段推断的消息类型
+type WebSocketMessageType struct {
+	Discriminator string `json:"discriminator,omitempty"` // 区分字段（type、event...，Socket.IO事件为 event）
+	Value         string `json:"value,omitempty"`         // 区分字段的值
+	Format        string `json:"format"`                  // json、text、binary
+	Sent          int    `json:"sent"`
+	Received      int    `json:"received"`
+	Bytes         int    `json:"bytes"`
+	GoType        string `json:"goType,omitempty"` // 生成的Go结构体名
+
+	payload *jsonShape
+}
+
+// 帧的数据大小（二进制帧为解码后的字节数）
+func (m HARWebSocketMessage) size() int {
+	if m.Opcode == webSocketOpcodeBinary {
+		if data, err := base64.StdEncoding.DecodeString(m.Data); err == nil {
+			return len(data)
+		}
+	}
+	return len(m.Data)
+}
+
+// 消息类型的显示名称（type=chat.message、event=join、json、text、binary）
+func (t WebSocketMessageType) Name() string {
+	if t.Discriminator != "" {
+		return t.Discriminator + "=" + t.Value
+	}
+	return t.Format
+}
+
+func (t WebSocketMessageType) key() string {
+	return t.Format + "\x00" + t.Discriminator + "\x00" + t.Value
+}
+
+// 分析所有带WebSocket帧的请求（没有WebSocket连接时返回nil）
+func (ua *UniversalHARAnalyzer) analyzeWebSockets(entries []HAREntry) *WebSocketAnalysis {
+	analysis := &WebSocketAnalysis{}
+	allTypes := make(map[string]*WebSocketMessageType)
+	var typeOrder []string
+
+	for i := range entries {
+		entry := &entries[i]
+		if len(entry.WebSocketMessages) == 0 && !strings.EqualFold(entry.ResourceType, "websocket") {
+			continue
+		}
+
+		normalized := normalizeURL(entry.Request.URL)
+		connection := WebSocketConnection{
+			URL:     ua.redactURL(entry.Request.URL),
+			Host:    normalized.Authority(),
+			Path:    normalized.Path,
+			Started: entry.StartedDateTime,
+		}
+		connectionTypes := make(map[string]*WebSocketMessageType)
+		var connectionOrder []string
+		first, last := math.Inf(1), math.Inf(-1)
+
+		for _, message := range entry.WebSocketMessages {
+			first = math.Min(first, message.Time)
+			last = math.Max(last, message.Time)
+
+			size := message.size()
+			switch message.Opcode {
+			case webSocketOpcodeText:
+				connection.TextFrames++
+			case webSocketOpcodeBinary:
+				connection.BinaryFrames++
+			case webSocketOpcodeClose, webSocketOpcodePing, webSocketOpcodePong:
+				connection.ControlFrames++
+				continue
+			default:
+				continue
+			}
+
+			sent := message.Type == "send"
+			if sent {
+				connection.Sent++
+				connection.SentBytes += size
+			} else {
+				connection.Received++
+				connection.ReceivedBytes += size
+			}
+			connection.MaxMessageSize = maxInt(connection.MaxMessageSize, size)
+
+			// 同时计入本连接和全局的消息类型
+			messageType, payload := classifyWebSocketMessage(message)
+			for _, types := range []struct {
+				byKey map[string]*WebSocketMessageType
+				order *[]string
+			}{{connectionTypes, &connectionOrder}, {allTypes, &typeOrder}} {
+				key := messageType.key()
+				existing, exists := types.byKey[key]
+				if !exists {
+					copied := messageType
+					copied.payload = newJSONShape()
+					existing = &copied
+					types.byKey[key] = existing
+					*types.order = append(*types.order, key)
+				}
+				if sent {
+					existing.Sent++
+				} else {
+					existing.Received++
+				}
+				existing.Bytes += size
+				if payload != nil {
+					existing.payload.add(payload)
+				}
+			}
+		}
+
+		if len(entry.WebSocketMessages) > 0 && last > first {
+			connection.Duration = roundMillis((last - first) * 1000)
+			connection.MessagesPerSecond = math.Round(float64(connection.Sent+connection.Received)/(last-first)*100) / 100
+		}
+		connection.MessageTypes = sortedWebSocketTypes(connectionTypes, connectionOrder)
+		analysis.Connections = append(analysis.Connections, connection)
+	}
+	if len(analysis.Connections) == 0 {
+		return nil
+	}
+
+	// 为JSON消息负载生成Go结构体
+	generator := newGoTypeGenerator()
+	goTypes := make(map[string]string)
+	for _, key := range typeOrder {
+		messageType := allTypes[key]
+		if messageType.payload.kind() != "object" || len(messageType.payload.fields) == 0 {
+			continue
+		}
+		name := "Message"
+		if messageType.Value != "" {
+			name = goTypeName(messageType.Value)
+			if !strings.HasSuffix(name, "Message") {
+				name += "Message"
+			}
+		}
+		messageType.GoType = strings.TrimPrefix(generator.generate(name, messageType.payload), "*")
+		goTypes[key] = messageType.GoType
+	}
+	analysis.GoTypes = generator.code()
+	analysis.MessageTypes = sortedWebSocketTypes(allTypes, typeOrder)
+	for i := range analysis.Connections {
+		for j := range analysis.Connections[i].MessageTypes {
+			messageType := &analysis.Connections[i].MessageTypes[j]
+			messageType.GoType = goTypes[messageType.key()]
+		}
+	}
+	return analysis
+}
+
+// 按消息数降序排列消息类型
+func sortedWebSocketTypes(types map[string]*WebSocketMessageType, order []string) []WebSocketMessageType {
+	sorted := make([]WebSocketMessageType, 0, len(order))
+	for _, key := range order {
+		sorted = append(sorted, *types[key])
+	}
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Sent+sorted[i].Received > sorted[j].Sent+sorted[j].Received
+	})
+	return sorted
+}
+
+// 推断消息类型，返回类型和用于生成结构体的JSON负载
+func classifyWebSocketMessage(message HARWebSocketMessage) (WebSocketMessageType, interface{}) {
+	if message.Opcode == webSocketOpcodeBinary {
+		return WebSocketMessageType{Format: "binary"}, nil
+	}
+
+	text := strings.TrimSpace(message.Data)
+	// Socket.IO / Engine.IO 帧：数字前缀 + JSON（42["event", {...}]）
+	if prefix := strings.IndexAny(text, "[{"); prefix > 0 && strings.Trim(text[:prefix], "0123456789") == "" {
+		text = text[prefix:]
+	}
+	if !json.Valid([]byte(text)) {
+		return WebSocketMessageType{Format: "text"}, nil
+	}
+	value, err := decodeJSONWithNumbers(text)
+	if err != nil {
+		return WebSocketMessageType{Format: "text"}, nil
+	}
+
+	switch v := value.(type) {
+	case map[string]interface{}:
+		if field, discriminator, ok := messageDiscriminator(v); ok {
+			return WebSocketMessageType{Format: "json", Discriminator: field, Value: discriminator}, v
+		}
+	case []interface{}:
+		// 事件数组：["event", payload]
+		if len(v) > 0 {
+			if event, ok := v[0].(string); ok && event != "" {
+				var payload interface{}
+				if len(v) > 1 {
+					payload = v[1]
+				}
+				return WebSocketMessageType{Format: "json", Discriminator: "event", Value: event}, payload
+			}
+		}
+	}
+	return WebSocketMessageType{Format: "json"}, value
+}
+
+// 查找JSON对象中的区分字段，返回字段名和值
+func messageDiscriminator(object map[string]interface{}) (string, string, bool) {
+	for _, field := range messageDiscriminators {
+		if value, ok := discriminatorValue(object[field]); ok {
+			return field, value, true
+		}
+	}
+	return "", "", false
+}
+
+// 区分字段的值（只接受较短的字符串或整数）
+func discriminatorValue(value interface{}) (string, bool) {
+	switch v := value.(type) {
+	case string:
+		return v, v != "" && len(v) <= 64
+	case json.Number:
+		if n, err := v.Int64(); err == nil {
+			return strconv.FormatInt(n, 10), true
+		}
+	}
+	return "", false
+}
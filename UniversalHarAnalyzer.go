@@ -1,11 +1,13 @@
 package main
 
 import (
+	"encoding/base64"
 	"encoding/json"
+	"flag"
 	"fmt"
 	"os"
+	"path"
 	"path/filepath"
-	"regexp"
 	"sort"
 	"strings"
 	"time"
@@ -23,71 +25,95 @@ type UniversalHARFile struct {
 			Name    string `json:"name"`
 			Version string `json:"version"`
 		} `json:"browser"`
-		Pages []struct {
-			StartedDateTime string `json:"startedDateTime"`
-			ID              string `json:"id"`
-			Title           string `json:"title"`
-			PageTimings     struct {
-				OnContentLoad float64 `json:"onContentLoad"`
-				OnLoad        float64 `json:"onLoad"`
-			} `json:"pageTimings"`
-		} `json:"pages"`
-		Entries []struct {
-			StartedDateTime string  `json:"startedDateTime"`
-			Time            float64 `json:"time"`
-			Request         struct {
-				Method      string `json:"method"`
-				URL         string `json:"url"`
-				HTTPVersion string `json:"httpVersion"`
-				Headers     []struct {
-					Name  string `json:"name"`
-					Value string `json:"value"`
-				} `json:"headers"`
-				QueryString []struct {
-					Name  string `json:"name"`
-					Value string `json:"value"`
-				} `json:"queryString"`
-				PostData struct {
-					MimeType string `json:"mimeType"`
-					Text     string `json:"text"`
-				} `json:"postData"`
-				HeadersSize float64 `json:"headersSize"`
-				BodySize    float64 `json:"bodySize"`
-			} `json:"request"`
-			Response struct {
-				Status      int    `json:"status"`
-				StatusText  string `json:"statusText"`
-				HTTPVersion string `json:"httpVersion"`
-				Headers     []struct {
-					Name  string `json:"name"`
-					Value string `json:"value"`
-				} `json:"headers"`
-				Content struct {
-					Size     float64 `json:"size"`
-					MimeType string  `json:"mimeType"`
-					Text     string  `json:"text"`
-				} `json:"content"`
-				RedirectURL string  `json:"redirectURL"`
-				HeadersSize float64 `json:"headersSize"`
-				BodySize    float64 `json:"bodySize"`
-			} `json:"response"`
-			Cache struct {
-				BeforeRequest interface{} `json:"beforeRequest"`
-				AfterRequest  interface{} `json:"afterRequest"`
-			} `json:"cache"`
-			Timings struct {
-				Blocked float64 `json:"blocked"`
-				DNS     float64 `json:"dns"`
-				Connect float64 `json:"connect"`
-				Send    float64 `json:"send"`
-				Wait    float64 `json:"wait"`
-				Receive float64 `json:"receive"`
-				SSL     float64 `json:"ssl"`
-			} `json:"timings"`
-		} `json:"entries"`
+		Pages   []HARPage  `json:"pages"`
+		Entries []HAREntry `json:"entries"`
 	} `json:"log"`
 }
 
+// HAR名值对（请求头、查询参数）
+type HARNameValue struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// HAR Cookie
+type HARCookie struct {
+	Name     string `json:"name"`
+	Value    string `json:"value"`
+	Path     string `json:"path"`
+	Domain   string `json:"domain"`
+	Expires  string `json:"expires"`
+	HTTPOnly bool   `json:"httpOnly"`
+	Secure   bool   `json:"secure"`
+}
+
+// HAR页面
+type HARPage struct {
+	StartedDateTime string `json:"startedDateTime"`
+	ID              string `json:"id"`
+	Title           string `json:"title"`
+	PageTimings     struct {
+		OnContentLoad float64 `json:"onContentLoad"`
+		OnLoad        float64 `json:"onLoad"`
+	} `json:"pageTimings"`
+}
+
+// HAR请求条目
+type HAREntry struct {
+	Pageref         string  `json:"pageref"`
+	StartedDateTime string  `json:"startedDateTime"`
+	Time            float64 `json:"time"`
+	Request         struct {
+		Method      string         `json:"method"`
+		URL         string         `json:"url"`
+		HTTPVersion string         `json:"httpVersion"`
+		Headers     []HARNameValue `json:"headers"`
+		Cookies     []HARCookie    `json:"cookies"`
+		QueryString []HARNameValue `json:"queryString"`
+		PostData    struct {
+			MimeType string         `json:"mimeType"`
+			Text     string         `json:"text"`
+			Params   []HARPostParam `json:"params"`
+		} `json:"postData"`
+		HeadersSize float64 `json:"headersSize"`
+		BodySize    float64 `json:"bodySize"`
+	} `json:"request"`
+	Response struct {
+		Status      int            `json:"status"`
+		StatusText  string         `json:"statusText"`
+		HTTPVersion string         `json:"httpVersion"`
+		Headers     []HARNameValue `json:"headers"`
+		Cookies     []HARCookie    `json:"cookies"`
+		Content     struct {
+			Size     float64 `json:"size"`
+			MimeType string  `json:"mimeType"`
+			Text     string  `json:"text"`
+			Encoding string  `json:"encoding"`
+		} `json:"content"`
+		RedirectURL  string  `json:"redirectURL"`
+		HeadersSize  float64 `json:"headersSize"`
+		BodySize     float64 `json:"bodySize"`
+		TransferSize float64 `json:"_transferSize,omitempty"` // Chrome扩展字段：实际传输的字节数
+	} `json:"response"`
+	Cache struct {
+		BeforeRequest interface{} `json:"beforeRequest"`
+		AfterRequest  interface{} `json:"afterRequest"`
+	} `json:"cache"`
+	Timings struct {
+		Blocked float64 `json:"blocked"`
+		DNS     float64 `json:"dns"`
+		Connect float64 `json:"connect"`
+		Send    float64 `json:"send"`
+		Wait    float64 `json:"wait"`
+		Receive float64 `json:"receive"`
+		SSL     float64 `json:"ssl"`
+	} `json:"timings"`
+	ResourceType      string                `json:"_resourceType,omitempty"`      // Chrome扩展字段：资源类型
+	FromCache         string                `json:"_fromCache,omitempty"`         // Chrome扩展字段：从缓存读取（memory、disk）
+	Priority          string                `json:"_priority,omitempty"`          // Chrome扩展字段：请求优先级（VeryHigh、High、Medium、Low...）
+	WebSocketMessages []HARWebSocketMessage `json:"_webSocketMessages,omitempty"` // Chrome扩展字段：WebSocket帧
+}
+
 // 通用分析结果
 type UniversalAnalysisResult struct {
 	Metadata struct {
@@ -98,10 +124,24 @@ type UniversalAnalysisResult struct {
 		TimeSpan      string    `json:"timeSpan"`
 		BrowserInfo   string    `json:"browserInfo"`
 		HARVersion    string    `json:"harVersion"`
+		FilePath      string    `json:"filePath,omitempty"`     // HAR文件路径
+		RelativePath  string    `json:"relativePath,omitempty"` // 相对扫描目录的路径
+		Filter        string    `json:"filter,omitempty"`       // 过滤条件
+		FilteredOut   int       `json:"filteredOut,omitempty"`  // 被过滤掉的请求数
 	} `json:"metadata"`
 
-	Hosts []HostInfo `json:"hosts"`
-	APIs  []APIInfo  `json:"apis"`
+	Hosts       []HostInfo           `json:"hosts"`
+	APIs        []APIInfo            `json:"apis"`
+	Latency     LatencyStats         `json:"latency"`               // 所有请求的耗时统计（毫秒）
+	GraphQL     *GraphQLAnalysis     `json:"graphql,omitempty"`     // GraphQL操作统计
+	WebSocket   *WebSocketAnalysis   `json:"websocket,omitempty"`   // WebSocket连接统计
+	Streams     *StreamAnalysis      `json:"streams,omitempty"`     // SSE和NDJSON流式响应统计
+	JSONRPC     *JSONRPCAnalysis     `json:"jsonrpc,omitempty"`     // JSON-RPC方法统计
+	GRPC        *GRPCAnalysis        `json:"grpc,omitempty"`        // gRPC-Web和protobuf请求统计
+	Redirects   *RedirectAnalysis    `json:"redirects,omitempty"`   // 重定向链
+	Caching     *CacheAnalysis       `json:"caching,omitempty"`     // 缓存审计
+	Compression *CompressionAnalysis `json:"compression,omitempty"` // 压缩审计
+	Pages       *PageAnalysis        `json:"pages,omitempty"`       // 页面加载性能
 
 	// 数据提取结果
 	ExtractedData struct {
@@ -138,17 +178,45 @@ type APIInfo struct {
 	ResponseType string                 `json:"responseType"`
 	StatusCode   int                    `json:"statusCode"`
 	CallCount    int                    `json:"callCount"`
+	ErrorCount   int                    `json:"errorCount"`          // 状态码为0或>=400的调用次数
+	Latency      LatencyStats           `json:"latency"`             // 耗时统计（毫秒）
+	Durations    []float64              `json:"durations,omitempty"` // 每次调用的耗时（毫秒），用于跨文件汇总
+	Operation    string                 `json:"operation,omitempty"` // SOAP操作名（同一路径按操作分组）
+	BodyFormat   string                 `json:"bodyFormat,omitempty"`
+	BodyFields   []BodyField            `json:"bodyFields,omitempty"` // 表单、multipart字段和XML元素路径
 }
 
 // 通用HAR分析器
 type UniversalHARAnalyzer struct {
-	outputDir string
+	outputDir      string
+	filter         *EntryFilter
+	exportFormats  []string     // 明细数据导出格式
+	exportColumns  []string     // CSV/NDJSON导出列
+	database       *HARDatabase // SQLite导出数据库（分析过程中打开）
+	config         *AnalyzerConfig
+	reportTemplate *ReportTemplate // 报告模板
+
+	// 输出文件命名
+	naming       string            // 命名方式（path、hash）
+	outputMode   string            // 写入模式（overwrite、timestamp、version）
+	scanRoots    []string          // 扫描目录，用于计算相对路径
+	runTimestamp int64             // 本次运行的时间戳
+	baseNames    map[string]string // HAR文件路径 -> 输出基础名
+	versions     map[string]int    // 输出基础名 -> 本次运行使用的版本号
+	manifest     *OutputManifest   // 本次运行生成的文件清单
+
+	// 分析结果缓存
+	cacheDir string // 缓存目录（为空时使用输出目录下的 .cache）
+	noCache  bool   // 禁用缓存
+
+	protoDescriptors *protoDescriptorSet // 用于解码protobuf的描述符集合（可选）
 }
 
 // 创建新的通用分析器
 func NewUniversalHARAnalyzer() *UniversalHARAnalyzer {
 	return &UniversalHARAnalyzer{
 		outputDir: "universal_har_analysis",
+		config:    DefaultAnalyzerConfig(),
 	}
 }
 
@@ -171,26 +239,103 @@ func (ua *UniversalHARAnalyzer) ScanHARFiles(dir string) ([]string, error) {
 	return harFiles, err
 }
 
-// 分析单个HAR文件
-func (ua *UniversalHARAnalyzer) AnalyzeHARFile(filePath string) (*UniversalAnalysisResult, error) {
-	fmt.Printf("📁 分析HAR文件: %s\n", filepath.Base(filePath))
+// 将命令行参数中的文件和目录展开为HAR文件列表（无参数时扫描当前目录）
+func (ua *UniversalHARAnalyzer) ResolveHARFiles(paths []string) ([]string, error) {
+	if len(paths) == 0 {
+		currentDir, _ := os.Getwd()
+		paths = []string{currentDir}
+	}
+
+	var harFiles []string
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, err
+		}
+		if !info.IsDir() {
+			harFiles = append(harFiles, path)
+			continue
+		}
+		files, err := ua.ScanHARFiles(path)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", T("err.scan_har"), err)
+		}
+		harFiles = append(harFiles, files...)
+	}
+
+	return harFiles, nil
+}
+
+// 设置请求过滤条件（表达式为空且不排除静态资源时不过滤）
+func (ua *UniversalHARAnalyzer) SetFilter(expression string, excludeStatic bool) error {
+	var filter *EntryFilter
+	if expression != "" {
+		parsed, err := parseEntryFilter(expression, ua)
+		if err != nil {
+			return err
+		}
+		filter = parsed
+	}
+	if excludeStatic {
+		filter = CombineEntryFilters(ExcludeStaticFilter(), filter)
+	}
+	ua.filter = filter
+	return nil
+}
+
+// 按过滤条件筛选请求
+func (ua *UniversalHARAnalyzer) selectEntries(entries []HAREntry) []HAREntry {
+	if ua.filter == nil {
+		return entries
+	}
+	var selected []HAREntry
+	for i := range entries {
+		if ua.filter.Match(&entries[i]) {
+			selected = append(selected, entries[i])
+		}
+	}
+	return selected
+}
 
-	// 读取HAR文件
+// 读取并解析HAR文件
+func (ua *UniversalHARAnalyzer) LoadHARFile(filePath string) (*UniversalHARFile, error) {
 	data, err := os.ReadFile(filePath)
 	if err != nil {
-		return nil, fmt.Errorf("读取HAR文件失败: %w", err)
+		return nil, fmt.Errorf("%s: %w", T("err.read_har"), err)
 	}
 
 	var harFile UniversalHARFile
 	if err := json.Unmarshal(data, &harFile); err != nil {
-		return nil, fmt.Errorf("解析HAR文件失败: %w", err)
+		return nil, fmt.Errorf("%s: %w", T("err.parse_har"), err)
 	}
 
+	return &harFile, nil
+}
+
+// 分析单个HAR文件
+func (ua *UniversalHARAnalyzer) AnalyzeHARFile(filePath string) (*UniversalAnalysisResult, error) {
+	fmt.Println("📁 " + T("analyze.file", filepath.Base(filePath)))
+
+	harFile, err := ua.LoadHARFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	return ua.AnalyzeHAR(harFile, filePath), nil
+}
+
+// 分析已解析的HAR数据
+func (ua *UniversalHARAnalyzer) AnalyzeHAR(harFile *UniversalHARFile, filePath string) *UniversalAnalysisResult {
+	entries := ua.selectEntries(harFile.Log.Entries)
+
 	// 初始化分析结果
 	result := &UniversalAnalysisResult{}
 	result.Metadata.FileName = filepath.Base(filePath)
+	result.Metadata.FilePath = filePath
 	result.Metadata.AnalysisTime = time.Now()
-	result.Metadata.TotalRequests = len(harFile.Log.Entries)
+	result.Metadata.TotalRequests = len(entries)
+	result.Metadata.Filter = ua.filter.String()
+	result.Metadata.FilteredOut = len(harFile.Log.Entries) - len(entries)
 	result.Metadata.HARVersion = harFile.Log.Version
 	result.Metadata.BrowserInfo = fmt.Sprintf("%s %s", harFile.Log.Browser.Name, harFile.Log.Browser.Version)
 
@@ -204,10 +349,13 @@ func (ua *UniversalHARAnalyzer) AnalyzeHARFile(filePath string) (*UniversalAnaly
 
 	hostMap := make(map[string]*HostInfo)
 	apiMap := make(map[string]*APIInfo)
+	graphQL := newGraphQLCollector(ua)
+	jsonRPC := newJSONRPCCollector(ua)
 
 	// 分析每个请求
 	var startTime, endTime time.Time
-	for i, entry := range harFile.Log.Entries {
+	var durations []float64
+	for i, entry := range entries {
 		// 解析时间
 		if entryTime, err := time.Parse(time.RFC3339, entry.StartedDateTime); err == nil {
 			if i == 0 || entryTime.Before(startTime) {
@@ -221,9 +369,10 @@ func (ua *UniversalHARAnalyzer) AnalyzeHARFile(filePath string) (*UniversalAnaly
 		// 解析URL
 		url := entry.Request.URL
 		method := entry.Request.Method
+		normalized := normalizeURL(url)
 
 		// 提取主机信息
-		if host := ua.extractHost(url); host != "" {
+		if host := normalized.Authority(); host != "" {
 			if _, exists := hostMap[host]; !exists {
 				hostMap[host] = &HostInfo{
 					Host:    host,
@@ -233,7 +382,7 @@ func (ua *UniversalHARAnalyzer) AnalyzeHARFile(filePath string) (*UniversalAnaly
 			}
 			hostMap[host].RequestCount++
 			ua.addUniqueString(&hostMap[host].Methods, method)
-			ua.addUniqueString(&hostMap[host].Paths, ua.extractPath(url))
+			ua.addUniqueString(&hostMap[host].Paths, normalized.Path)
 		}
 
 		// 统计HTTP方法
@@ -248,14 +397,28 @@ func (ua *UniversalHARAnalyzer) AnalyzeHARFile(filePath string) (*UniversalAnaly
 			result.ExtractedData.Headers[header.Name]++
 		}
 
-		// 分析查询参数
-		for _, param := range entry.Request.QueryString {
+		// 分析查询参数（补充 queryString 中缺失的URL参数）
+		queryParams := mergeQueryParams(entry.Request.QueryString, normalized.Query)
+		for _, param := range queryParams {
 			result.ExtractedData.Parameters[param.Name]++
 		}
 
-		// 分析POST数据中的参数
-		if entry.Request.PostData.Text != "" {
-			ua.extractPostParameters(entry.Request.PostData.Text, result.ExtractedData.Parameters)
+		// 分析POST数据中的参数（GraphQL请求只统计变量，JSON-RPC请求只统计params）
+		var body requestBody
+		if graphQLRequests := ua.graphQLRequests(&entries[i]); len(graphQLRequests) > 0 {
+			graphQL.add(&entries[i], normalized.Authority()+normalized.Path, graphQLRequests)
+			for _, request := range graphQLRequests {
+				ua.extractJSONKeys(request.variables, "", result.ExtractedData.Parameters)
+			}
+		} else if jsonRPCRequests := ua.jsonRPCRequests(&entries[i]); len(jsonRPCRequests) > 0 {
+			jsonRPC.add(&entries[i], normalized.Authority()+normalized.Path, jsonRPCRequests)
+			for _, request := range jsonRPCRequests {
+				if params, ok := request.params.(map[string]interface{}); ok {
+					ua.extractJSONKeys(params, "", result.ExtractedData.Parameters)
+				}
+			}
+		} else {
+			body = ua.extractPostParameters(&entries[i], result.ExtractedData.Parameters)
 		}
 
 		// 分析响应类型
@@ -266,33 +429,44 @@ func (ua *UniversalHARAnalyzer) AnalyzeHARFile(filePath string) (*UniversalAnaly
 		}
 
 		// 创建API信息
-		apiKey := fmt.Sprintf("%s %s", method, ua.extractPath(url))
+		apiKey := fmt.Sprintf("%s %s", method, normalized.Path)
+		if body.operation != "" {
+			apiKey += " " + body.operation
+		}
 		if _, exists := apiMap[apiKey]; !exists {
 			apiMap[apiKey] = &APIInfo{
 				Method:       method,
-				URL:          url,
-				Host:         ua.extractHost(url),
-				Path:         ua.extractPath(url),
+				URL:          ua.redactURL(url),
+				Host:         normalized.Authority(),
+				Path:         normalized.Path,
 				Parameters:   make(map[string]interface{}),
 				Headers:      make(map[string]string),
 				ResponseType: ua.simplifyContentType(contentType),
 				StatusCode:   entry.Response.Status,
 				CallCount:    0,
+				Operation:    body.operation,
+				BodyFormat:   body.format,
 			}
 
 			// 收集参数
-			for _, param := range entry.Request.QueryString {
-				apiMap[apiKey].Parameters[param.Name] = param.Value
+			for _, param := range queryParams {
+				apiMap[apiKey].Parameters[param.Name] = ua.redactParam(param.Name, param.Value)
 			}
 
 			// 收集重要请求头
 			for _, header := range entry.Request.Headers {
 				if ua.isImportantHeader(header.Name) {
-					apiMap[apiKey].Headers[header.Name] = header.Value
+					apiMap[apiKey].Headers[header.Name] = ua.redactHeader(header.Name, header.Value)
 				}
 			}
 		}
+		ua.addBodyFields(apiMap[apiKey], body.fields)
 		apiMap[apiKey].CallCount++
+		apiMap[apiKey].Durations = append(apiMap[apiKey].Durations, roundMillis(entry.Time))
+		if entry.Response.Status == 0 || entry.Response.Status >= 400 {
+			apiMap[apiKey].ErrorCount++
+		}
+		durations = append(durations, entry.Time)
 	}
 
 	// 转换map为slice
@@ -300,8 +474,19 @@ func (ua *UniversalHARAnalyzer) AnalyzeHARFile(filePath string) (*UniversalAnaly
 		result.Hosts = append(result.Hosts, *host)
 	}
 	for _, api := range apiMap {
+		api.Latency = computeLatencyStats(api.Durations)
 		result.APIs = append(result.APIs, *api)
 	}
+	result.Latency = computeLatencyStats(durations)
+	result.GraphQL = graphQL.result()
+	result.JSONRPC = jsonRPC.result()
+	result.WebSocket = ua.analyzeWebSockets(entries)
+	result.Streams = ua.analyzeStreams(entries)
+	result.GRPC = ua.analyzeGRPC(entries)
+	result.Redirects = ua.analyzeRedirects(entries)
+	result.Caching = ua.analyzeCaching(entries)
+	result.Compression = ua.analyzeCompression(entries)
+	result.Pages = ua.analyzePages(harFile.Log.Pages, entries)
 
 	// 排序
 	sort.Slice(result.Hosts, func(i, j int) bool {
@@ -314,7 +499,7 @@ func (ua *UniversalHARAnalyzer) AnalyzeHARFile(filePath string) (*UniversalAnaly
 	// 设置时间跨度
 	result.Metadata.UniqueHosts = len(hostMap)
 	if !startTime.IsZero() && !endTime.IsZero() {
-		result.Metadata.TimeSpan = fmt.Sprintf("%s - %s (%.1f分钟)",
+		result.Metadata.TimeSpan = T("analyze.time_span",
 			startTime.Format("15:04:05"),
 			endTime.Format("15:04:05"),
 			endTime.Sub(startTime).Minutes())
@@ -323,27 +508,112 @@ func (ua *UniversalHARAnalyzer) AnalyzeHARFile(filePath string) (*UniversalAnaly
 	// 生成代码模板
 	ua.generateCodeTemplates(result)
 
-	return result, nil
+	return result
 }
 
-// 提取主机名
-func (ua *UniversalHARAnalyzer) extractHost(url string) string {
-	re := regexp.MustCompile(`https?://([^/]+)`)
-	matches := re.FindStringSubmatch(url)
-	if len(matches) > 1 {
-		return matches[1]
+// 属于指定API（方法+路径+SOAP操作名）的请求
+func (ua *UniversalHARAnalyzer) apiEntries(entries []HAREntry, method, path, operation string) []HAREntry {
+	var matched []HAREntry
+	for _, entry := range entries {
+		if entry.Request.Method == method && ua.extractPath(entry.Request.URL) == path && parseRequestBody(&entry).operation == operation {
+			matched = append(matched, entry)
+		}
 	}
-	return ""
+	return matched
+}
+
+// 提取主机名（含非默认端口）
+func (ua *UniversalHARAnalyzer) extractHost(url string) string {
+	return normalizeURL(url).Authority()
 }
 
-// 提取路径
+// 提取规范化路径
 func (ua *UniversalHARAnalyzer) extractPath(url string) string {
-	re := regexp.MustCompile(`https?://[^/]+(/[^?#]*)`)
-	matches := re.FindStringSubmatch(url)
-	if len(matches) > 1 {
-		return matches[1]
+	return normalizeURL(url).Path
+}
+
+// 响应体文本（base64编码的内容会先解码）
+func responseBodyText(entry *HAREntry) string {
+	if entry.Response.Content.Encoding == "base64" {
+		if data, err := base64.StdEncoding.DecodeString(entry.Response.Content.Text); err == nil {
+			return string(data)
+		}
+	}
+	return entry.Response.Content.Text
+}
+
+// 响应实际传输的字节数（优先使用 _transferSize，其次为响应头和响应体大小，都没有时为内容大小）
+func transferBytes(entry *HAREntry) int64 {
+	if entry.Response.TransferSize > 0 {
+		return int64(entry.Response.TransferSize)
+	}
+	if entry.Response.BodySize >= 0 && entry.Response.HeadersSize >= 0 && entry.Response.BodySize+entry.Response.HeadersSize > 0 {
+		return int64(entry.Response.BodySize + entry.Response.HeadersSize)
+	}
+	if entry.Response.BodySize > 0 {
+		return int64(entry.Response.BodySize)
+	}
+	if entry.Response.Content.Size > 0 {
+		return int64(entry.Response.Content.Size)
+	}
+	return 0
+}
+
+// 按扩展名识别的图片和字体
+var (
+	imageExtensions = map[string]bool{".png": true, ".jpg": true, ".jpeg": true, ".gif": true, ".svg": true, ".ico": true, ".webp": true, ".avif": true, ".bmp": true}
+	fontExtensions  = map[string]bool{".woff": true, ".woff2": true, ".ttf": true, ".otf": true, ".eot": true}
+)
+
+// 资源类型（优先使用 _resourceType，其次按内容类型，最后按扩展名判断）：document、stylesheet、script、image、font、media、xhr、other
+func resourceKind(entry *HAREntry) string {
+	switch kind := strings.ToLower(entry.ResourceType); kind {
+	case "document", "stylesheet", "script", "image", "font", "media":
+		return kind
+	case "xhr", "fetch", "websocket", "eventsource":
+		return "xhr"
+	}
+
+	// MIME类型优先，无法识别时（如 text/plain、application/octet-stream）再看扩展名
+	mimeType := strings.ToLower(entry.Response.Content.MimeType)
+	switch {
+	case strings.Contains(mimeType, "css"):
+		return "stylesheet"
+	case strings.Contains(mimeType, "javascript") || strings.Contains(mimeType, "ecmascript"):
+		return "script"
+	case strings.HasPrefix(mimeType, "image/"):
+		return "image"
+	case strings.Contains(mimeType, "font"):
+		return "font"
+	case strings.HasPrefix(mimeType, "video/") || strings.HasPrefix(mimeType, "audio/"):
+		return "media"
+	case strings.Contains(mimeType, "html"):
+		return "document"
+	case strings.Contains(mimeType, "json") || strings.Contains(mimeType, "xml"):
+		return "xhr"
+	}
+
+	switch ext := strings.ToLower(path.Ext(normalizeURL(entry.Request.URL).Path)); {
+	case ext == ".css":
+		return "stylesheet"
+	case ext == ".js" || ext == ".mjs":
+		return "script"
+	case imageExtensions[ext]:
+		return "image"
+	case fontExtensions[ext]:
+		return "font"
+	}
+	return "other"
+}
+
+// 按名称查找请求头或响应头的值（不区分大小写）
+func headerValue(headers []HARNameValue, name string) string {
+	for _, header := range headers {
+		if strings.EqualFold(header.Name, name) {
+			return header.Value
+		}
 	}
-	return "/"
+	return ""
 }
 
 // 添加唯一字符串到切片
@@ -356,23 +626,41 @@ func (ua *UniversalHARAnalyzer) addUniqueString(slice *[]string, str string) {
 	*slice = append(*slice, str)
 }
 
-// 提取POST参数
-func (ua *UniversalHARAnalyzer) extractPostParameters(postData string, params map[string]int) {
-	// 尝试解析JSON
-	var jsonData map[string]interface{}
-	if err := json.Unmarshal([]byte(postData), &jsonData); err == nil {
-		ua.extractJSONKeys(jsonData, "", params)
-		return
+// 提取POST参数，返回解析后的请求体
+func (ua *UniversalHARAnalyzer) extractPostParameters(entry *HAREntry, params map[string]int) requestBody {
+	body := parseRequestBody(entry)
+	switch data := body.json.(type) {
+	case map[string]interface{}:
+		ua.extractJSONKeys(data, "", params)
+	case []interface{}:
+		// 顶层数组的每个元素按相同前缀统计（[].id）
+		for _, item := range data {
+			ua.extractJSONKeys(item, "[]", params)
+		}
+	}
+	for _, field := range body.fields {
+		params[field.name]++
 	}
+	return body
+}
 
-	// 尝试解析表单数据
-	if strings.Contains(postData, "=") && strings.Contains(postData, "&") {
-		pairs := strings.Split(postData, "&")
-		for _, pair := range pairs {
-			if kv := strings.SplitN(pair, "=", 2); len(kv) == 2 {
-				params[kv[0]]++
+// 合并请求体字段到API信息（按字段名去重）
+func (ua *UniversalHARAnalyzer) addBodyFields(api *APIInfo, fields []bodyField) {
+	for _, field := range fields {
+		exists := false
+		for _, existing := range api.BodyFields {
+			if existing.Name == field.name {
+				exists = true
+				break
 			}
 		}
+		if !exists {
+			value := ""
+			if field.fileName == "" {
+				value = ua.redactParam(field.name, field.value)
+			}
+			api.BodyFields = append(api.BodyFields, BodyField{Name: field.name, Value: value, FileName: field.fileName, ContentType: field.contentType})
+		}
 	}
 }
 
@@ -398,41 +686,21 @@ func (ua *UniversalHARAnalyzer) extractJSONKeys(data interface{}, prefix string,
 
 // 简化内容类型
 func (ua *UniversalHARAnalyzer) simplifyContentType(contentType string) string {
-	if strings.Contains(contentType, "json") {
-		return "JSON"
-	}
-	if strings.Contains(contentType, "html") {
-		return "HTML"
-	}
-	if strings.Contains(contentType, "xml") {
-		return "XML"
-	}
-	if strings.Contains(contentType, "javascript") {
-		return "JavaScript"
-	}
-	if strings.Contains(contentType, "css") {
-		return "CSS"
-	}
-	if strings.Contains(contentType, "image") {
-		return "Image"
-	}
-	if strings.Contains(contentType, "text") {
-		return "Text"
+	for _, rule := range ua.settings().ContentTypes {
+		for _, keyword := range rule.Match {
+			if strings.Contains(contentType, keyword) {
+				return rule.Type
+			}
+		}
 	}
 	return "Other"
 }
 
 // 判断是否为重要请求头
 func (ua *UniversalHARAnalyzer) isImportantHeader(headerName string) bool {
-	important := []string{
-		"authorization", "cookie", "content-type", "accept",
-		"user-agent", "referer", "origin", "x-requested-with",
-		"x-csrf-token", "x-api-key", "bearer", "token",
-	}
-
 	headerLower := strings.ToLower(headerName)
-	for _, imp := range important {
-		if strings.Contains(headerLower, imp) {
+	for _, imp := range ua.settings().ImportantHeaders {
+		if strings.Contains(headerLower, strings.ToLower(imp)) {
 			return true
 		}
 	}
@@ -479,8 +747,8 @@ func (ua *UniversalHARAnalyzer) generateAPIEndpoints(result *UniversalAnalysisRe
 	var endpoints []string
 
 	for _, api := range result.APIs {
-		if api.CallCount > 1 { // 只包含调用次数大于1的API
-			endpoint := fmt.Sprintf("// %s %s (调用%d次)", api.Method, api.Path, api.CallCount)
+		if api.CallCount > ua.settings().Thresholds.APICalls { // 只包含调用次数超过阈值的API
+			endpoint := "// " + T("codegen.api_endpoint", api.Method, api.DisplayPath(), api.CallCount)
 			endpoints = append(endpoints, endpoint)
 		}
 	}
@@ -500,7 +768,7 @@ func (ua *UniversalHARAnalyzer) generateCommonHeaders(result *UniversalAnalysisR
 
 	var headerCounts []headerCount
 	for name, count := range result.ExtractedData.Headers {
-		if count > 1 && ua.isImportantHeader(name) {
+		if count > ua.settings().Thresholds.TemplateHeaders && ua.isImportantHeader(name) {
 			headerCounts = append(headerCounts, headerCount{name, count})
 		}
 	}
@@ -510,7 +778,7 @@ func (ua *UniversalHARAnalyzer) generateCommonHeaders(result *UniversalAnalysisR
 	})
 
 	for _, hc := range headerCounts {
-		headers = append(headers, fmt.Sprintf("req.Header.Set(\"%s\", \"your_value_here\") // 出现%d次", hc.name, hc.count))
+		headers = append(headers, fmt.Sprintf("req.Header.Set(\"%s\", \"your_value_here\") // %s", hc.name, T("codegen.header_count", hc.count)))
 	}
 
 	return headers
@@ -520,55 +788,102 @@ func (ua *UniversalHARAnalyzer) generateCommonHeaders(result *UniversalAnalysisR
 func (ua *UniversalHARAnalyzer) AnalyzeAllHARFiles(dir string) error {
 	// 创建输出目录
 	if err := os.MkdirAll(ua.outputDir, 0755); err != nil {
-		return fmt.Errorf("创建输出目录失败: %w", err)
+		return fmt.Errorf("%s: %w", T("err.create_output_dir"), err)
 	}
 
 	// 扫描HAR文件
 	harFiles, err := ua.ScanHARFiles(dir)
 	if err != nil {
-		return fmt.Errorf("扫描HAR文件失败: %w", err)
+		return fmt.Errorf("%s: %w", T("err.scan_har"), err)
 	}
 
 	if len(harFiles) == 0 {
-		fmt.Println("❌ 未找到HAR文件")
+		fmt.Println("❌ " + T("common.no_har_files"))
 		return nil
 	}
 
-	fmt.Printf("🔍 发现 %d 个HAR文件\n", len(harFiles))
+	fmt.Println("🔍 " + T("analyze.found_files", len(harFiles)))
+	ua.beginOutput(harFileRoots([]string{dir})...)
+
+	// 准备明细数据导出
+	if err := ua.beginExports(); err != nil {
+		return err
+	}
 
-	// 分析每个文件
+	// 分析每个文件（未变化的文件使用缓存结果）
+	var results []*UniversalAnalysisResult
+	cachedCount := 0
 	for i, filePath := range harFiles {
 		fmt.Printf("\n[%d/%d] ", i+1, len(harFiles))
-
-		result, err := ua.AnalyzeHARFile(filePath)
+		result, cached, err := ua.processHARFile(filePath)
 		if err != nil {
-			fmt.Printf("❌ 分析失败: %v\n", err)
+			fmt.Println("❌ " + T("analyze.failed", err))
 			continue
 		}
+		if cached {
+			cachedCount++
+		}
+		results = append(results, result)
+	}
 
-		// 保存分析结果
-		if err := ua.saveAnalysisResult(result); err != nil {
-			fmt.Printf("⚠️ 保存结果失败: %v\n", err)
-		} else {
-			fmt.Printf("✅ 分析完成: %d个请求, %d个主机, %d个API\n",
-				result.Metadata.TotalRequests,
-				result.Metadata.UniqueHosts,
-				len(result.APIs))
+	ua.finishExports()
+	ua.updateSummary(results)
+
+	if cachedCount > 0 {
+		fmt.Println("\n♻️ " + T("cache.summary", len(results)-cachedCount, cachedCount))
+	}
+	fmt.Println("\n🎉 " + T("analyze.all_done", ua.outputDir))
+	return nil
+}
+
+// 分析单个HAR文件，保存分析结果并导出明细数据
+func (ua *UniversalHARAnalyzer) processHARFile(filePath string) (*UniversalAnalysisResult, bool, error) {
+	fmt.Println("📁 " + T("analyze.file", filepath.Base(filePath)))
+
+	result, harFile, cached, err := ua.analyzeHARFileCached(filePath)
+	if err != nil {
+		return nil, false, err
+	}
+	if cached {
+		fmt.Println("♻️ " + T("cache.hit"))
+	}
+	result.Metadata.RelativePath = ua.relativeHARPath(filePath)
+
+	// 导出明细数据
+	if harFile != nil {
+		if err := ua.exportEntries(harFile, filePath); err != nil {
+			fmt.Println("⚠️ " + T("export.failed", err))
 		}
 	}
 
-	// 生成汇总报告
-	if err := ua.generateSummaryReport(harFiles); err != nil {
-		fmt.Printf("⚠️ 生成汇总报告失败: %v\n", err)
+	// 保存分析结果
+	if err := ua.saveAnalysisResult(result); err != nil {
+		fmt.Println("⚠️ " + T("common.save_failed", err))
+	} else {
+		fmt.Println("✅ " + T("analyze.file_done",
+			result.Metadata.TotalRequests,
+			result.Metadata.UniqueHosts,
+			len(result.APIs)))
 	}
+	return result, cached, nil
+}
 
-	fmt.Printf("\n🎉 分析完成! 结果保存在: %s\n", ua.outputDir)
-	return nil
+// 生成汇总报告并写入输出清单
+func (ua *UniversalHARAnalyzer) updateSummary(results []*UniversalAnalysisResult) {
+	if err := ua.generateSummaryReport(results); err != nil {
+		fmt.Println("⚠️ " + T("summary.failed", err))
+	}
+	if err := ua.writeManifest(); err != nil {
+		fmt.Println("⚠️ " + T("output.manifest_failed", err))
+	}
 }
 
 // 保存分析结果
 func (ua *UniversalHARAnalyzer) saveAnalysisResult(result *UniversalAnalysisResult) error {
-	timestamp := time.Now().Unix()
+	baseName, err := ua.outputBaseName(result.Metadata.FilePath)
+	if err != nil {
+		return err
+	}
 
 	// 保存JSON结果
 	jsonData, err := json.MarshalIndent(result, "", "  ")
@@ -576,195 +891,143 @@ func (ua *UniversalHARAnalyzer) saveAnalysisResult(result *UniversalAnalysisResu
 		return err
 	}
 
-	jsonFile := filepath.Join(ua.outputDir, fmt.Sprintf("%s_analysis_%d.json",
-		strings.TrimSuffix(result.Metadata.FileName, ".har"), timestamp))
-
+	jsonFile := ua.artifactPath(baseName, "analysis", ".json")
 	if err := os.WriteFile(jsonFile, jsonData, 0644); err != nil {
 		return err
 	}
+	if err := ua.recordArtifact(jsonFile, "analysis", result.Metadata.FilePath); err != nil {
+		return err
+	}
 
-	// 生成Markdown报告
-	if err := ua.generateMarkdownReport(result, timestamp); err != nil {
+	// 生成报告
+	if err := ua.generateReport(result, baseName); err != nil {
 		return err
 	}
 
 	return nil
 }
 
-// 生成Markdown报告
-func (ua *UniversalHARAnalyzer) generateMarkdownReport(result *UniversalAnalysisResult, timestamp int64) error {
-	var report strings.Builder
-
-	report.WriteString(fmt.Sprintf("# HAR分析报告: %s\n\n", result.Metadata.FileName))
-	report.WriteString(fmt.Sprintf("**分析时间**: %s\n\n", result.Metadata.AnalysisTime.Format("2006-01-02 15:04:05")))
-
-	// 基本信息
-	report.WriteString("## 📊 基本信息\n\n")
-	report.WriteString(fmt.Sprintf("- **总请求数**: %d\n", result.Metadata.TotalRequests))
-	report.WriteString(fmt.Sprintf("- **唯一主机数**: %d\n", result.Metadata.UniqueHosts))
-	report.WriteString(fmt.Sprintf("- **时间跨度**: %s\n", result.Metadata.TimeSpan))
-	report.WriteString(fmt.Sprintf("- **浏览器**: %s\n", result.Metadata.BrowserInfo))
-	report.WriteString(fmt.Sprintf("- **HAR版本**: %s\n\n", result.Metadata.HARVersion))
-
-	// 主机统计
-	report.WriteString("## 🌐 主机统计\n\n")
-	report.WriteString("| 主机 | 请求数 | HTTP方法 |\n")
-	report.WriteString("|------|--------|----------|\n")
-	for _, host := range result.Hosts {
-		methods := strings.Join(host.Methods, ", ")
-		report.WriteString(fmt.Sprintf("| %s | %d | %s |\n", host.Host, host.RequestCount, methods))
-	}
-	report.WriteString("\n")
-
-	// API统计
-	report.WriteString("## 🔗 热门API (调用次数 > 1)\n\n")
-	report.WriteString("| 方法 | 路径 | 主机 | 调用次数 | 响应类型 |\n")
-	report.WriteString("|------|------|------|----------|----------|\n")
-	for _, api := range result.APIs {
-		if api.CallCount > 1 {
-			report.WriteString(fmt.Sprintf("| %s | %s | %s | %d | %s |\n",
-				api.Method, api.Path, api.Host, api.CallCount, api.ResponseType))
-		}
-	}
-	report.WriteString("\n")
-
-	// 参数统计
-	report.WriteString("## 📝 常用参数 (出现次数 > 1)\n\n")
-	ua.writeTopItems(&report, result.ExtractedData.Parameters, "参数名", "出现次数")
-
-	// 请求头统计
-	report.WriteString("## 📋 常用请求头 (出现次数 > 5)\n\n")
-	ua.writeTopItemsFiltered(&report, result.ExtractedData.Headers, "请求头", "出现次数", 5)
-
-	// HTTP方法统计
-	report.WriteString("## 🔧 HTTP方法统计\n\n")
-	ua.writeTopItems(&report, result.ExtractedData.Methods, "方法", "使用次数")
-
-	// 状态码统计
-	report.WriteString("## 📈 状态码统计\n\n")
-	ua.writeTopItems(&report, result.ExtractedData.StatusCodes, "状态码", "出现次数")
-
-	// 响应类型统计
-	report.WriteString("## 📄 响应类型统计\n\n")
-	ua.writeTopItems(&report, result.ExtractedData.ResponseTypes, "类型", "出现次数")
-
-	// 代码模板
-	report.WriteString("## 💻 代码模板\n\n")
-
-	report.WriteString("### Go结构体\n\n")
-	for _, goStruct := range result.CodeTemplates.GoStructs {
-		report.WriteString("```go\n")
-		report.WriteString(goStruct)
-		report.WriteString("\n```\n\n")
-	}
-
-	report.WriteString("### 常用请求头设置\n\n")
-	report.WriteString("```go\n")
-	for _, header := range result.CodeTemplates.Headers {
-		report.WriteString(header + "\n")
-	}
-	report.WriteString("```\n\n")
-
-	report.WriteString("### API端点列表\n\n")
-	report.WriteString("```go\n")
-	for _, endpoint := range result.CodeTemplates.APIEndpoints {
-		report.WriteString(endpoint + "\n")
+// 使用报告模板生成报告
+func (ua *UniversalHARAnalyzer) generateReport(result *UniversalAnalysisResult, baseName string) error {
+	report, ext, err := ua.renderReport(result)
+	if err != nil {
+		return err
 	}
-	report.WriteString("```\n\n")
 
 	// 保存报告
-	reportFile := filepath.Join(ua.outputDir, fmt.Sprintf("%s_report_%d.md",
-		strings.TrimSuffix(result.Metadata.FileName, ".har"), timestamp))
-
-	return os.WriteFile(reportFile, []byte(report.String()), 0644)
+	reportFile := ua.artifactPath(baseName, "report", ext)
+	if err := os.WriteFile(reportFile, report, 0644); err != nil {
+		return err
+	}
+	return ua.recordArtifact(reportFile, "report", result.Metadata.FilePath)
 }
 
-// 写入排序后的统计项
-func (ua *UniversalHARAnalyzer) writeTopItems(report *strings.Builder, items map[string]int, nameHeader, countHeader string) {
-	ua.writeTopItemsFiltered(report, items, nameHeader, countHeader, 1)
+// 写入Markdown表头
+func writeTableHeader(report *strings.Builder, headers ...string) {
+	report.WriteString("| " + strings.Join(headers, " | ") + " |\n")
+	separators := make([]string, len(headers))
+	for i := range separators {
+		separators[i] = "------"
+	}
+	report.WriteString("|" + strings.Join(separators, "|") + "|\n")
 }
 
-// 写入过滤后的排序统计项
-func (ua *UniversalHARAnalyzer) writeTopItemsFiltered(report *strings.Builder, items map[string]int, nameHeader, countHeader string, minCount int) {
-	type item struct {
-		name  string
-		count int
+func main() {
+	// 输出语言（-lang 参数优先，其次根据环境变量自动选择）
+	if err := initLanguage(os.Args[1:]); err != nil {
+		fmt.Println("❌ " + err.Error())
+		os.Exit(1)
 	}
 
-	var sortedItems []item
-	for name, count := range items {
-		if count > minCount {
-			sortedItems = append(sortedItems, item{name, count})
+	// 子命令
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "loadscript":
+			if err := runLoadScriptCommand(os.Args[2:]); err != nil {
+				fmt.Println("❌ " + T("loadscript.failed", err))
+				os.Exit(1)
+			}
+			return
+		case "load":
+			if err := runLoadCommand(os.Args[2:]); err != nil {
+				fmt.Println("❌ " + T("load.failed", err))
+				os.Exit(1)
+			}
+			return
+		case "query":
+			if err := runQueryCommand(os.Args[2:]); err != nil {
+				fmt.Println("❌ " + T("query.failed", err))
+				os.Exit(1)
+			}
+			return
+		case "watch":
+			if err := runWatchCommand(os.Args[2:]); err != nil {
+				fmt.Println("❌ " + T("watch.failed", err))
+				os.Exit(1)
+			}
+			return
+		case "ui":
+			if err := runUICommand(os.Args[2:]); err != nil {
+				fmt.Println("❌ " + T("ui.failed", err))
+				os.Exit(1)
+			}
+			return
+		case "tui":
+			if err := runTUICommand(os.Args[2:]); err != nil {
+				fmt.Println("❌ " + T("tui.failed", err))
+				os.Exit(1)
+			}
+			return
 		}
 	}
 
-	sort.Slice(sortedItems, func(i, j int) bool {
-		return sortedItems[i].count > sortedItems[j].count
-	})
+	analyzer := NewUniversalHARAnalyzer()
 
-	if len(sortedItems) == 0 {
-		report.WriteString("无数据\n\n")
-		return
+	flags := flag.NewFlagSet(filepath.Base(os.Args[0]), flag.ExitOnError)
+	flags.StringVar(&analyzer.outputDir, "o", analyzer.outputDir, T("flag.output_dir"))
+	filter := flags.String("filter", "", T("flag.filter"))
+	excludeStatic := flags.Bool("exclude-static", false, T("flag.exclude_static"))
+	exports := flags.String("export", "", T("flag.export"))
+	columns := flags.String("columns", "", T("flag.columns"))
+	reportTemplate := flags.String("template", defaultReportTemplate, T("flag.template"))
+	addOutputNamingFlags(flags)
+	addCacheFlags(flags, analyzer)
+	addProtoFlag(flags)
+	configPath := addConfigFlag(flags)
+	addLanguageFlag(flags)
+	flags.Parse(os.Args[1:])
+
+	if err := analyzer.Configure(flags, *configPath, *filter, *excludeStatic); err != nil {
+		fmt.Printf("❌ %v\n", err)
+		os.Exit(1)
 	}
 
-	report.WriteString(fmt.Sprintf("| %s | %s |\n", nameHeader, countHeader))
-	report.WriteString("|------|------|\n")
-
-	maxItems := 20 // 最多显示20项
-	for i, item := range sortedItems {
-		if i >= maxItems {
-			report.WriteString(fmt.Sprintf("| ... | ... |\n"))
-			report.WriteString(fmt.Sprintf("| **总计**: %d项 | |\n", len(sortedItems)))
-			break
-		}
-		report.WriteString(fmt.Sprintf("| %s | %d |\n", item.name, item.count))
+	fmt.Println("🚀 " + T("analyze.start"))
+	fmt.Println("====================")
+	if err := analyzer.SetReportTemplate(*reportTemplate); err != nil {
+		fmt.Printf("❌ %v\n", err)
+		os.Exit(1)
 	}
-	report.WriteString("\n")
-}
-
-// 生成汇总报告
-func (ua *UniversalHARAnalyzer) generateSummaryReport(harFiles []string) error {
-	var summary strings.Builder
-
-	summary.WriteString("# 通用HAR分析汇总报告\n\n")
-	summary.WriteString(fmt.Sprintf("**生成时间**: %s\n\n", time.Now().Format("2006-01-02 15:04:05")))
-	summary.WriteString(fmt.Sprintf("**分析文件数**: %d\n\n", len(harFiles)))
-
-	summary.WriteString("## 📁 分析的文件列表\n\n")
-	for i, file := range harFiles {
-		summary.WriteString(fmt.Sprintf("%d. %s\n", i+1, filepath.Base(file)))
+	if err := analyzer.SetExportFormats(*exports); err != nil {
+		fmt.Printf("❌ %v\n", err)
+		os.Exit(1)
+	}
+	if err := analyzer.SetExportColumns(*columns); err != nil {
+		fmt.Printf("❌ %v\n", err)
+		os.Exit(1)
 	}
-	summary.WriteString("\n")
-
-	summary.WriteString("## 📋 使用说明\n\n")
-	summary.WriteString("1. 每个HAR文件都生成了对应的JSON分析结果和Markdown报告\n")
-	summary.WriteString("2. JSON文件包含完整的结构化数据，可用于程序处理\n")
-	summary.WriteString("3. Markdown报告提供人类可读的分析结果\n")
-	summary.WriteString("4. 代码模板可以直接复制使用\n\n")
-
-	summary.WriteString("## 🔧 生成的文件说明\n\n")
-	summary.WriteString("- `*_analysis_*.json`: 结构化分析数据\n")
-	summary.WriteString("- `*_report_*.md`: 可读性分析报告\n")
-	summary.WriteString("- `summary_report.md`: 本汇总报告\n\n")
-
-	summaryFile := filepath.Join(ua.outputDir, "summary_report.md")
-	return os.WriteFile(summaryFile, []byte(summary.String()), 0644)
-}
-
-func main() {
-	fmt.Println("🚀 通用HAR分析器启动")
-	fmt.Println("====================")
-
-	analyzer := NewUniversalHARAnalyzer()
 
-	// 分析当前目录下的所有HAR文件
+	// 分析指定目录（默认当前目录）下的所有HAR文件
 	currentDir, _ := os.Getwd()
+	if flags.NArg() > 0 {
+		currentDir = flags.Arg(0)
+	}
 
 	if err := analyzer.AnalyzeAllHARFiles(currentDir); err != nil {
-		fmt.Printf("❌ 分析失败: %v\n", err)
+		fmt.Println("❌ " + T("analyze.failed", err))
 		os.Exit(1)
 	}
 
-	fmt.Println("\n🎯 分析完成!")
-	fmt.Printf("📂 查看结果: %s\n", analyzer.outputDir)
+	fmt.Println("\n🎯 " + T("analyze.done"))
+	fmt.Println("📂 " + T("common.view_results", analyzer.outputDir))
 }
@@ -13,7 +13,7 @@ if not exist "builds" mkdir builds
 echo 编译 Windows x86 (32位)...
 set GOOS=windows
 set GOARCH=386
-go build -o builds/%PROGRAM_NAME%-windows-x86.exe %PROGRAM_NAME%.go
+go build -o builds/%PROGRAM_NAME%-windows-x86.exe .
 if %errorlevel% equ 0 (
     echo ✓ Windows x86 编译成功
 ) else (
@@ -24,7 +24,7 @@ if %errorlevel% equ 0 (
 echo 编译 Windows amd64 (64位)...
 set GOOS=windows
 set GOARCH=amd64
-go build -o builds/%PROGRAM_NAME%-windows-amd64.exe %PROGRAM_NAME%.go
+go build -o builds/%PROGRAM_NAME%-windows-amd64.exe .
 if %errorlevel% equ 0 (
     echo ✓ Windows amd64 编译成功
 ) else (
@@ -35,7 +35,7 @@ if %errorlevel% equ 0 (
 echo 编译 Linux x86 (32位)...
 set GOOS=linux
 set GOARCH=386
-go build -o builds/%PROGRAM_NAME%-linux-x86 %PROGRAM_NAME%.go
+go build -o builds/%PROGRAM_NAME%-linux-x86 .
 if %errorlevel% equ 0 (
     echo ✓ Linux x86 编译成功
 ) else (
@@ -46,7 +46,7 @@ if %errorlevel% equ 0 (
 echo 编译 Linux amd64 (64位)...
 set GOOS=linux
 set GOARCH=amd64
-go build -o builds/%PROGRAM_NAME%-linux-amd64 %PROGRAM_NAME%.go
+go build -o builds/%PROGRAM_NAME%-linux-amd64 .
 if %errorlevel% equ 0 (
     echo ✓ Linux amd64 编译成功
 ) else (
@@ -58,7 +58,7 @@ echo 编译 Linux ARM v7 (32位)...
 set GOOS=linux
 set GOARCH=arm
 set GOARM=7
-go build -o builds/%PROGRAM_NAME%-linux-armv7 %PROGRAM_NAME%.go
+go build -o builds/%PROGRAM_NAME%-linux-armv7 .
 if %errorlevel% equ 0 (
     echo ✓ Linux ARM v7 编译成功
 ) else (
@@ -69,7 +69,7 @@ if %errorlevel% equ 0 (
 echo 编译 Linux ARM64 v8 (64位)...
 set GOOS=linux
 set GOARCH=arm64
-go build -o builds/%PROGRAM_NAME%-linux-armv8 %PROGRAM_NAME%.go
+go build -o builds/%PROGRAM_NAME%-linux-armv8 .
 if %errorlevel% equ 0 (
     echo ✓ Linux ARM64 v8 编译成功
 ) else (
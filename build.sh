@@ -19,9 +19,9 @@ build_target() {
     echo "编译 $output_name..."
     
     if [ "$arch" = "arm" ] && [ -n "$arm_version" ]; then
-        GOOS=$os GOARCH=$arch GOARM=$arm_version go build -o builds/${PROGRAM_NAME}-${output_name} ${PROGRAM_NAME}.go
+        GOOS=$os GOARCH=$arch GOARM=$arm_version go build -o builds/${PROGRAM_NAME}-${output_name} .
     else
-        GOOS=$os GOARCH=$arch go build -o builds/${PROGRAM_NAME}-${output_name} ${PROGRAM_NAME}.go
+        GOOS=$os GOARCH=$arch go build -o builds/${PROGRAM_NAME}-${output_name} .
     fi
     
     if [ $? -eq 0 ]; then
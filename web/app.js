@@ -0,0 +1,349 @@
+'use strict';
+
+// 当前语言的消息
+let messages = {};
+// 状态
+const state = { files: [], file: null, analysis: null, tab: 'overview', api: null, calls: [], call: null, sort: {}, filter: '' };
+
+function t(key, ...args) {
+  let text = messages[key] || key;
+  args.forEach(arg => { text = text.replace(/%[sdvq]/, String(arg)); });
+  return text;
+}
+
+function el(tag, attrs, ...children) {
+  const node = document.createElement(tag);
+  Object.entries(attrs || {}).forEach(([name, value]) => {
+    if (name.startsWith('on')) node.addEventListener(name.slice(2), value);
+    else if (name === 'className') node.className = value;
+    else node.setAttribute(name, value);
+  });
+  children.flat().forEach(child => {
+    if (child === null || child === undefined) return;
+    node.append(child instanceof Node ? child : document.createTextNode(String(child)));
+  });
+  return node;
+}
+
+async function api(path, options) {
+  const response = await fetch(path, options);
+  const body = await response.json();
+  if (!response.ok) throw new Error(body.error || response.statusText);
+  return body;
+}
+
+function setStatus(text, isError) {
+  const status = document.getElementById('status');
+  status.textContent = text || '';
+  status.className = isError ? 'error' : '';
+}
+
+function formatMillis(ms) {
+  if (ms === undefined || ms === null) return '-';
+  return ms < 1000 ? `${Math.round(ms)}ms` : `${(ms / 1000).toFixed(2)}s`;
+}
+
+function formatBytes(size) {
+  if (!(size >= 0)) return '-';
+  const units = ['B', 'KB', 'MB', 'GB'];
+  let unit = 0;
+  while (size >= 1024 && unit < units.length - 1) { size /= 1024; unit++; }
+  return unit === 0 ? `${size} B` : `${size.toFixed(1)} ${units[unit]}`;
+}
+
+// 响应体为JSON时格式化显示
+function prettyBody(text) {
+  if (!text) return t('web.empty');
+  try { return JSON.stringify(JSON.parse(text), null, 2); } catch (e) { return text; }
+}
+
+// 可排序、可过滤的表格；columns: [{title, value(row), numeric, render(row)}]，unfiltered 表示不受过滤框影响
+function table(id, columns, rows, onClick, selected, unfiltered) {
+  const sort = state.sort[id] || {};
+  let sorted = rows.slice();
+  if (sort.column !== undefined) {
+    const column = columns[sort.column];
+    sorted.sort((a, b) => {
+      const x = column.value(a), y = column.value(b);
+      const order = column.numeric ? x - y : String(x).localeCompare(String(y));
+      return sort.desc ? -order : order;
+    });
+  }
+  if (state.filter && !unfiltered) {
+    const needle = state.filter.toLowerCase();
+    sorted = sorted.filter(row => columns.some(column => String(column.value(row)).toLowerCase().includes(needle)));
+  }
+
+  const header = el('tr', null, columns.map((column, i) => el('th', {
+    onclick: () => {
+      state.sort[id] = { column: i, desc: sort.column === i ? !sort.desc : !!column.numeric };
+      render();
+    },
+  }, column.title, sort.column === i ? (sort.desc ? ' ▼' : ' ▲') : '')));
+  const body = sorted.map(row => el('tr', {
+    className: (onClick ? 'clickable' : '') + (selected && selected(row) ? ' selected' : ''),
+    onclick: onClick ? () => onClick(row) : null,
+  }, columns.map(column => el('td', { className: column.numeric ? 'num' : '' },
+    column.render ? column.render(row) : column.value(row)))));
+  return el('table', null, el('thead', null, header), el('tbody', null, body));
+}
+
+function countRows(counts) {
+  return Object.entries(counts || {}).map(([name, count]) => ({ name, count }));
+}
+
+function filterInput() {
+  const input = el('input', { className: 'filter', placeholder: t('web.filter'), value: state.filter });
+  input.addEventListener('input', () => {
+    state.filter = input.value;
+    const position = input.selectionStart;
+    render();
+    const next = document.querySelector('input.filter');
+    next.focus();
+    next.setSelectionRange(position, position);
+  });
+  return input;
+}
+
+async function loadFiles() {
+  state.files = await api('/api/files');
+  const list = document.getElementById('files');
+  list.replaceChildren(...state.files.map(file => el('li', {
+    className: state.file && state.file.id === file.id ? 'active' : '',
+    onclick: () => openFile(file),
+  }, file.name, ' ', el('small', null, formatBytes(file.size), file.uploaded ? ` · ${t('web.uploaded')}` : ''))));
+  if (state.files.length === 0) list.replaceChildren(el('li', null, t('web.no_files')));
+
+  ['compare-a', 'compare-b'].forEach((id, i) => {
+    const select = document.getElementById(id);
+    const current = select.value;
+    select.replaceChildren(...state.files.map(file => el('option', { value: file.id }, file.name)));
+    if (current) select.value = current;
+    else if (state.files[i]) select.value = state.files[i].id;
+  });
+}
+
+async function openFile(file) {
+  setStatus(t('web.loading'));
+  try {
+    state.analysis = await api(`/api/files/${file.id}/analysis`);
+    Object.assign(state, { file, tab: 'overview', api: null, calls: [], call: null, filter: '' });
+    setStatus('');
+    await loadFiles();
+    render();
+  } catch (e) {
+    setStatus(t('web.error', e.message), true);
+  }
+}
+
+async function openAPI(row) {
+  setStatus(t('web.loading'));
+  try {
+    const query = new URLSearchParams({ method: row.method, path: row.path });
+    if (row.operation) query.set('operation', row.operation);
+    state.calls = await api(`/api/files/${state.file.id}/calls?${query}`);
+    Object.assign(state, { api: row, call: state.calls[0] || null, filter: '' });
+    setStatus('');
+    render();
+  } catch (e) {
+    setStatus(t('web.error', e.message), true);
+  }
+}
+
+async function upload(input) {
+  if (!input.files.length) return;
+  const form = new FormData();
+  form.append('file', input.files[0]);
+  setStatus(t('web.uploading'));
+  try {
+    const file = await api('/api/files', { method: 'POST', body: form });
+    setStatus('');
+    await loadFiles();
+    await openFile(file);
+  } catch (e) {
+    setStatus(t('web.error', e.message), true);
+  }
+  input.value = '';
+}
+
+async function compare() {
+  const a = document.getElementById('compare-a').value;
+  const b = document.getElementById('compare-b').value;
+  if (!a || !b) return;
+  setStatus(t('web.loading'));
+  try {
+    const summary = await api(`/api/compare?a=${a}&b=${b}`);
+    Object.assign(state, { file: null, analysis: null, comparison: summary, tab: 'compare', filter: '' });
+    setStatus('');
+    await loadFiles();
+    render();
+  } catch (e) {
+    setStatus(t('web.error', e.message), true);
+  }
+}
+
+function renderOverview(analysis) {
+  const meta = analysis.metadata;
+  const rows = [
+    [t('report.total_requests'), meta.totalRequests],
+    [t('report.unique_hosts'), meta.uniqueHosts],
+    [t('report.time_span'), meta.timeSpan],
+    [t('report.browser'), meta.browserInfo],
+    [t('report.har_version'), meta.harVersion],
+    [t('col.mean'), formatMillis(analysis.latency.mean)],
+    ['P95', formatMillis(analysis.latency.p95)],
+  ];
+  if (meta.filter) rows.push([t('report.filter'), meta.filter]);
+  return [
+    el('dl', { className: 'meta' }, rows.map(([name, value]) => [el('dt', null, name), el('dd', null, value)])),
+    el('h3', null, t('report.status_codes')),
+    table('status', [
+      { title: t('col.status'), value: row => row.name },
+      { title: t('col.occurrences'), value: row => row.count, numeric: true },
+    ], countRows(analysis.extractedData.statusCodes)),
+    el('h3', null, t('report.methods')),
+    table('methods', [
+      { title: t('col.method'), value: row => row.name },
+      { title: t('col.occurrences'), value: row => row.count, numeric: true },
+    ], countRows(analysis.extractedData.methods)),
+  ];
+}
+
+function renderAPIs(analysis) {
+  const views = [filterInput(), table('apis', [
+    { title: t('col.method'), value: row => row.method },
+    { title: t('col.host'), value: row => row.host },
+    { title: t('col.path'), value: row => row.path },
+    { title: t('col.calls'), value: row => row.callCount, numeric: true },
+    { title: t('col.errors'), value: row => row.errorCount, numeric: true },
+    { title: t('col.mean'), value: row => row.latency.mean, numeric: true, render: row => formatMillis(row.latency.mean) },
+    { title: 'P95', value: row => row.latency.p95, numeric: true, render: row => formatMillis(row.latency.p95) },
+    { title: t('col.response_type'), value: row => row.responseType },
+  ], analysis.apis || [], openAPI, row => state.api && row.method === state.api.method && row.path === state.api.path)];
+  if (state.api) views.push(renderCalls());
+  return views;
+}
+
+function renderCalls() {
+  const callTable = table('calls', [
+    { title: '#', value: row => row.index + 1, numeric: true },
+    { title: t('col.status'), value: row => row.status, numeric: true, render: row => el('span', { className: row.status === 0 || row.status >= 400 ? 'status-error' : '' }, row.status) },
+    { title: 'URL', value: row => row.url },
+    { title: t('web.time'), value: row => row.time, numeric: true, render: row => formatMillis(row.time) },
+    { title: t('web.size'), value: row => row.size, numeric: true, render: row => formatBytes(row.size) },
+  ], state.calls, row => { state.call = row; render(); }, row => state.call && row.index === state.call.index, true);
+
+  const call = state.call;
+  const headerTable = (id, headers) => table(id, [
+    { title: t('col.header'), value: row => row.name },
+    { title: t('web.value'), value: row => row.value },
+  ], headers, null, null, true);
+  return el('div', { className: 'detail' },
+    el('h2', null, t('web.calls', state.api.method, state.api.path)),
+    callTable,
+    call ? [
+      el('h3', null, `${call.method} ${call.url}`),
+      el('p', null, `${call.status} ${call.statusText} · ${call.started} · ${formatMillis(call.time)} · ${call.mimeType}`),
+      el('h3', null, t('web.request_headers')), headerTable('request-headers', call.requestHeaders),
+      el('h3', null, t('web.request_body')), el('pre', null, prettyBody(call.requestBody)),
+      el('h3', null, t('web.response_headers')), headerTable('response-headers', call.responseHeaders),
+      el('h3', null, t('web.response_body')), el('pre', null, prettyBody(call.responseBody)),
+    ] : null);
+}
+
+function renderComparison(summary) {
+  const [a, b] = summary.files;
+  return [
+    el('h2', null, t('web.compare_title', a.relativePath || a.fileName, b.relativePath || b.fileName)),
+    table('compare-files', [
+      { title: t('col.file'), value: row => `${row.label} ${row.relativePath || row.fileName}` },
+      { title: t('col.requests'), value: row => row.totalRequests, numeric: true },
+      { title: t('col.hosts'), value: row => row.uniqueHosts, numeric: true },
+      { title: t('col.apis'), value: row => row.apiCount, numeric: true },
+      { title: t('col.errors'), value: row => row.errors, numeric: true },
+      { title: t('col.mean'), value: row => row.latency.mean, numeric: true, render: row => formatMillis(row.latency.mean) },
+      { title: 'P95', value: row => row.latency.p95, numeric: true, render: row => formatMillis(row.latency.p95) },
+    ], summary.files, null, null, true),
+    filterInput(),
+    table('compare-endpoints', [
+      { title: t('col.method'), value: row => row.method },
+      { title: t('col.host'), value: row => row.host },
+      { title: t('col.path'), value: row => row.path },
+      { title: 'F1', value: row => row.fileCounts[0], numeric: true, render: row => el('span', { className: row.fileCounts[0] ? '' : 'missing' }, row.fileCounts[0] || '—') },
+      { title: 'F2', value: row => row.fileCounts[1], numeric: true, render: row => el('span', { className: row.fileCounts[1] ? '' : 'missing' }, row.fileCounts[1] || '—') },
+      { title: 'Δ', value: row => row.fileCounts[1] - row.fileCounts[0], numeric: true },
+      { title: 'P95', value: row => row.latency.p95, numeric: true, render: row => formatMillis(row.latency.p95) },
+    ], summary.endpoints || []),
+  ];
+}
+
+function render() {
+  const main = document.getElementById('main');
+  if (state.tab === 'compare' && state.comparison) {
+    main.replaceChildren(...renderComparison(state.comparison));
+    return;
+  }
+  const analysis = state.analysis;
+  if (!analysis) {
+    main.replaceChildren(el('p', null, t('web.select_file')));
+    return;
+  }
+
+  const tabs = [
+    ['overview', t('report.basic_info')],
+    ['hosts', t('report.hosts')],
+    ['apis', t('web.apis')],
+    ['parameters', t('web.parameters')],
+    ['headers', t('web.headers')],
+  ];
+  const nav = el('nav', { className: 'tabs' }, tabs.map(([id, title]) => el('button', {
+    className: state.tab === id ? 'active' : '',
+    onclick: () => { state.tab = id; state.filter = ''; render(); },
+  }, title)));
+  const base = `/api/files/${state.file.id}/export/`;
+  const downloads = el('div', { className: 'downloads' }, t('web.downloads'), ': ',
+    ['csv', 'ndjson', 'json', 'report'].map(format => el('a', { href: base + format }, format.toUpperCase())));
+
+  let content;
+  switch (state.tab) {
+    case 'hosts':
+      content = [filterInput(), table('hosts', [
+        { title: t('col.host'), value: row => row.host },
+        { title: t('col.requests'), value: row => row.requestCount, numeric: true },
+        { title: t('col.http_methods'), value: row => (row.methods || []).join(', ') },
+        { title: t('col.path'), value: row => (row.paths || []).length, numeric: true },
+      ], analysis.hosts || [])];
+      break;
+    case 'apis':
+      content = renderAPIs(analysis);
+      break;
+    case 'parameters':
+      content = [filterInput(), table('parameters', [
+        { title: t('col.param'), value: row => row.name },
+        { title: t('col.occurrences'), value: row => row.count, numeric: true },
+      ], countRows(analysis.extractedData.parameters))];
+      break;
+    case 'headers':
+      content = [filterInput(), table('headers', [
+        { title: t('col.header'), value: row => row.name },
+        { title: t('col.uses'), value: row => row.count, numeric: true },
+      ], countRows(analysis.extractedData.headers))];
+      break;
+    default:
+      content = renderOverview(analysis);
+  }
+  main.replaceChildren(el('h2', null, state.file.name), downloads, nav, ...content);
+}
+
+async function init() {
+  const catalog = await api('/api/messages');
+  messages = catalog.messages;
+  document.documentElement.lang = catalog.language;
+  document.querySelectorAll('[data-t]').forEach(node => { node.textContent = t(node.dataset.t); });
+  document.getElementById('upload').addEventListener('change', event => upload(event.target));
+  document.getElementById('compare-run').addEventListener('click', compare);
+  await loadFiles();
+  render();
+}
+
+init().catch(e => setStatus(t('web.error', e.message), true));